@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/sommerfeld-io/source2adoc/internal/antora"
 	"github.com/spf13/cobra"
 )
 
@@ -12,7 +13,10 @@ Generates artifacts and documentation pages, including a nav.adoc file,
 specifically for Antora modules. It is designed to work with existing Antora
 modules that already contain documentation.
 
-You can use source2adoc to generate contents into an Antora module.
+You can use source2adoc to generate contents into an Antora module. The
+antora command walks the pages directory of the module and writes a nav.adoc
+file to the module root. The nesting of the navigation mirrors the directory
+tree of the pages directory.
 
 Example:
   source2adoc antora --module path/to/module
@@ -31,10 +35,19 @@ var antoraCmd = &cobra.Command{
 	Args: cobra.ExactArgs(0),
 
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Do stuff for module " + moduleDir)
+		writeNav(moduleDir)
 	},
 }
 
+// writeNav generates the nav.adoc file for the Antora module.
+func writeNav(dir string) {
+	module := antora.NewModule(dir)
+	err := module.WriteNav()
+	handleError(err)
+
+	fmt.Println(module.PagesDir() + "    ==>    " + module.NavFile())
+}
+
 func init() {
 	var params = []struct {
 		name     string
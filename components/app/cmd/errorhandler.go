@@ -1,6 +1,13 @@
 package cmd
 
-import "log"
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"sync"
+)
 
 // handleError handles all Errors of this application. See "Error Handling in our
 // Go Code" in the Development Guide (`docs/modules/ROOT/pages/development-guide.adoc`).
@@ -12,3 +19,94 @@ func handleError(err error) {
 		log.Fatal(err)
 	}
 }
+
+// stage represents a step of the conversion process. Errors of each stage result in a distinct
+// exit code.
+type stage struct {
+	name     string
+	exitCode int
+}
+
+// The stages of the conversion process in the order of their execution. Reading a code file is
+// part of the parse stage.
+var (
+	stageDiscovery = stage{name: "discovery", exitCode: 2}
+	stageParse     = stage{name: "parse", exitCode: 3}
+	stageWrite     = stage{name: "write", exitCode: 4}
+)
+
+// stageError is an error which occurred in a stage of the conversion process.
+type stageError struct {
+	stage stage
+	err   error
+}
+
+// errorCollector collects the errors of single files, so the remaining files are processed
+// anyway. In fail-fast mode, the first error ends the app immediately (just like handleError).
+// The beforeExit function (if any) is called right before the app ends. Errors can be added
+// concurrently (e.g. while the code files are searched in the background).
+type errorCollector struct {
+	mutex      sync.Mutex
+	failFast   bool
+	errors     []stageError
+	out        io.Writer
+	exit       func(code int)
+	beforeExit func()
+}
+
+func newErrorCollector(failFast bool) *errorCollector {
+	return &errorCollector{
+		failFast: failFast,
+		errors:   []stageError{},
+		out:      os.Stderr,
+		exit:     os.Exit,
+	}
+}
+
+// add records an error of the given stage. Errors joined with `errors.Join` are recorded one by
+// one. Nil errors are ignored.
+func (collector *errorCollector) add(stage stage, err error) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err := range joined.Unwrap() {
+			collector.add(stage, err)
+		}
+		return
+	}
+	if err == nil {
+		return
+	}
+
+	collector.mutex.Lock()
+	collector.errors = append(collector.errors, stageError{stage: stage, err: err})
+	collector.mutex.Unlock()
+	if collector.failFast {
+		collector.report()
+	}
+}
+
+// collected returns a copy of all errors collected so far.
+func (collector *errorCollector) collected() []stageError {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	return slices.Clone(collector.errors)
+}
+
+// report prints a summary of all collected errors and ends the app. The exit code is the exit
+// code of the earliest stage with errors. Without errors, nothing happens.
+func (collector *errorCollector) report() {
+	errors := collector.collected()
+	if len(errors) == 0 {
+		return
+	}
+
+	exitCode := stageWrite.exitCode
+	fmt.Fprintf(collector.out, "Errors (%d):\n", len(errors))
+	for _, stageErr := range errors {
+		fmt.Fprintf(collector.out, "  %-10s %v\n", stageErr.stage.name, stageErr.err)
+		exitCode = min(exitCode, stageErr.stage.exitCode)
+	}
+	if collector.beforeExit != nil {
+		collector.beforeExit()
+	}
+	collector.exit(exitCode)
+}
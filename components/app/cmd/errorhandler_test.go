@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestErrorCollector(failFast bool) (*errorCollector, *bytes.Buffer, *[]int) {
+	out := &bytes.Buffer{}
+	exitCodes := &[]int{}
+	collector := newErrorCollector(failFast)
+	collector.out = out
+	collector.exit = func(code int) {
+		*exitCodes = append(*exitCodes, code)
+	}
+	return collector, out, exitCodes
+}
+
+func Test_ShouldReportCollectedErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		stages   []stage
+		expected int
+	}{
+		{stages: []stage{stageWrite}, expected: 4},
+		{stages: []stage{stageWrite, stageParse}, expected: 3},
+		{stages: []stage{stageParse, stageDiscovery, stageWrite}, expected: 2},
+	}
+
+	for _, test := range tests {
+		collector, out, exitCodes := newTestErrorCollector(false)
+		for _, stage := range test.stages {
+			collector.add(stage, errors.New("src/script.sh: failed"))
+		}
+		assert.Empty(*exitCodes, "Errors should be collected without exiting")
+
+		collector.report()
+		assert.Equal([]int{test.expected}, *exitCodes, "Incorrect exit code")
+		assert.Contains(out.String(), "  "+test.stages[0].name, "Errors should be reported with stage")
+		assert.Contains(out.String(), "src/script.sh: failed\n", "Errors should be reported with path")
+	}
+}
+
+func Test_ShouldIgnoreNilErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	collector, out, exitCodes := newTestErrorCollector(false)
+	collector.add(stageParse, nil)
+	collector.add(stageParse, errors.Join(nil, nil))
+	collector.report()
+
+	assert.Empty(*exitCodes, "Report without errors should not exit")
+	assert.Empty(out.String(), "Report without errors should not print anything")
+}
+
+func Test_ShouldCollectJoinedErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	collector, out, _ := newTestErrorCollector(false)
+	collector.add(stageDiscovery, errors.Join(errors.New("src/a.sh: failed"), errors.New("src/b.sh: failed")))
+	collector.report()
+
+	assert.Len(collector.collected(), 2, "Joined errors should be collected one by one")
+	assert.Contains(out.String(), "Errors (2):\n", "Incorrect summary")
+}
+
+func Test_ShouldExitOnFirstErrorInFailFastMode(t *testing.T) {
+	assert := assert.New(t)
+
+	collector, out, exitCodes := newTestErrorCollector(true)
+	calls := 0
+	collector.beforeExit = func() { calls++ }
+	collector.add(stageWrite, errors.New("docs/script-sh.adoc: failed"))
+
+	assert.Equal([]int{4}, *exitCodes, "First error should exit")
+	assert.Equal(1, calls, "Function before exit should be called")
+	assert.Contains(out.String(), "docs/script-sh.adoc: failed", "Error should be reported")
+}
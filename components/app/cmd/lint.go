@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sommerfeld-io/source2adoc/internal/codefiles"
+	"github.com/sommerfeld-io/source2adoc/internal/config"
+	"github.com/spf13/cobra"
+)
+
+const lintDescShort = "Report undocumented code files, Bash functions and Makefile targets."
+const lintDescLong = `
+Checks the documentation coverage of all code files in the --source-dir
+without writing anything. The lint command uses the same settings as the
+root command (config file, includes, excludes, language mappings and
+ignore files).
+
+Every supported code file is expected to have header documentation. Bash
+scripts are expected to document all functions and Makefiles are expected
+to document all targets with ## comments. The lint command lists all
+undocumented items and the overall coverage percentage.
+
+Use --min-coverage (or min-coverage in the config file) to fail when the
+coverage is below a threshold, e.g. in a CI pipeline.
+
+Example:
+  source2adoc lint --source-dir ./src --min-coverage 80
+
+Example (Docker):
+  docker run -v "$(pwd):$(pwd)" -w "$(pwd)" sommerfeldio/source2adoc:latest lint -s ./src --min-coverage 80
+`
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: lintDescShort,
+	Long:  lintDescLong,
+
+	Args: cobra.ExactArgs(0),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := loadLintConfig(cmd)
+		handleError(err)
+
+		errs := newErrorCollector(false)
+		files := findCodeFiles(cfg, errs)
+		coverage := lintCodeFiles(files, errs)
+
+		writeLintReport(os.Stdout, coverage)
+		errs.report()
+		checkCoverage(coverage, cfg.MinCoverage)
+	},
+}
+
+// fileCoverage holds the coverage items of a single code file.
+type fileCoverage struct {
+	source string
+	items  []codefiles.CoverageItem
+}
+
+// loadLintConfig reads the config file and applies the CLI flags of the lint command on top of it.
+func loadLintConfig(cmd *cobra.Command) (*config.Config, error) {
+	cfg, err := readConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("min-coverage") {
+		minCoverage, err := flags.GetFloat64("min-coverage")
+		if err != nil {
+			return nil, err
+		}
+		cfg.MinCoverage = minCoverage
+	}
+	return cfg, cfg.ValidateLint()
+}
+
+// lintCodeFiles parses all code files and returns their coverage items. Code files which cannot
+// be read or parsed are passed to the errorCollector.
+func lintCodeFiles(files []*codefiles.CodeFile, errs *errorCollector) []fileCoverage {
+	coverage := []fileCoverage{}
+	for _, file := range files {
+		result := parseCodeFile(file)
+		if result.err != nil {
+			errs.add(result.stage, result.err)
+			continue
+		}
+		coverage = append(coverage, fileCoverage{
+			source: file.SourcePath(),
+			items:  file.Coverage(),
+		})
+	}
+	return coverage
+}
+
+// writeLintReport lists all undocumented items and the overall coverage.
+func writeLintReport(out io.Writer, coverage []fileCoverage) {
+	items := allCoverageItems(coverage)
+	documented := 0
+	for _, file := range coverage {
+		for _, item := range file.items {
+			if item.Documented {
+				documented++
+				continue
+			}
+			if item.Kind == codefiles.CoverageItemHeader {
+				fmt.Fprintf(out, "%s: %s\n", file.source, codefiles.WarningNoHeaderDocs)
+				continue
+			}
+			fmt.Fprintf(out, "%s: %s %s is not documented\n", file.source, item.Kind, item.Name)
+		}
+	}
+	fmt.Fprintf(out, "documentation coverage: %d of %d items documented (%.1f%%)\n", documented, len(items), codefiles.CoveragePercent(items))
+}
+
+// checkCoverage ends the app if the overall coverage is below the given minimum.
+func checkCoverage(coverage []fileCoverage, minCoverage float64) {
+	percent := codefiles.CoveragePercent(allCoverageItems(coverage))
+	if percent < minCoverage {
+		handleError(fmt.Errorf("documentation coverage %.1f%% is below the minimum of %.1f%%", percent, minCoverage))
+	}
+}
+
+// allCoverageItems returns the coverage items of all code files.
+func allCoverageItems(coverage []fileCoverage) []codefiles.CoverageItem {
+	items := []codefiles.CoverageItem{}
+	for _, file := range coverage {
+		items = append(items, file.items...)
+	}
+	return items
+}
+
+func init() {
+	addSourceFlags(lintCmd)
+	lintCmd.Flags().Float64("min-coverage", 0, "Fail if the documentation coverage (in percent) is below this value")
+
+	RegisterSubCommand(lintCmd)
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sommerfeld-io/source2adoc/internal/codefiles"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LintCmd(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := lintCmd
+
+	assert.Equal("lint", cmd.Use, "Incorrect command name")
+
+	flags := cmd.Flags()
+	assert.NotNil(flags.Lookup("source-dir"), "Missing --source-dir flag")
+	assert.NotNil(flags.Lookup("config"), "Missing --config flag")
+	assert.NotNil(flags.Lookup("include"), "Missing --include flag")
+	assert.NotNil(flags.Lookup("exclude"), "Missing --exclude flag")
+	assert.NotNil(flags.Lookup("language"), "Missing --language flag")
+	assert.NotNil(flags.Lookup("gitignore"), "Missing --gitignore flag")
+	assert.NotNil(flags.Lookup("min-coverage"), "Missing --min-coverage flag")
+}
+
+func Test_ShouldWriteLintReport(t *testing.T) {
+	assert := assert.New(t)
+
+	coverage := []fileCoverage{
+		{source: "src/script.sh", items: []codefiles.CoverageItem{
+			{Kind: codefiles.CoverageItemHeader, Name: "script.sh", Documented: true},
+			{Kind: codefiles.CoverageItemFunction, Name: "cleanup", Documented: false},
+		}},
+		{source: "src/Makefile", items: []codefiles.CoverageItem{
+			{Kind: codefiles.CoverageItemHeader, Name: "Makefile", Documented: false},
+			{Kind: codefiles.CoverageItemTarget, Name: "build", Documented: true},
+		}},
+	}
+
+	out := &bytes.Buffer{}
+	writeLintReport(out, coverage)
+
+	expected := "src/script.sh: function cleanup is not documented\n" +
+		"src/Makefile: " + codefiles.WarningNoHeaderDocs + "\n" +
+		"documentation coverage: 2 of 4 items documented (50.0%)\n"
+	assert.Equal(expected, out.String(), "Incorrect lint report")
+}
@@ -1,9 +1,19 @@
 package cmd
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"runtime"
 	"strings"
 
 	"github.com/sommerfeld-io/source2adoc/internal/codefiles"
+	"github.com/sommerfeld-io/source2adoc/internal/config"
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+	"github.com/sommerfeld-io/source2adoc/internal/pipeline"
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+	"github.com/sommerfeld-io/source2adoc/internal/report"
 	"github.com/spf13/cobra"
 )
 
@@ -24,26 +34,61 @@ Quick Start:
 Example:
   source2adoc --source-dir ./src --output-dir ./docs
 
+Config File:
+  All settings can be defined in a .source2adoc.yml file in the working
+  directory (or any other file passed with --config). Flags override the
+  values from the config file. All patterns are relative to each source dir.
+` + configExample + `
 Example (Docker):
   docker run -v "$(pwd):$(pwd)" -w "$(pwd)" sommerfeldio/source2adoc:latest -s ./src -o ./docs
 
 Supported Languages:
   `
 
+// configExample is the example config file from the help text.
+const configExample = `
+  source-dirs:
+    - src
+    - bin
+  output-dir: docs
+  format: asciidoc
+  include:
+    - '**/*.sh'
+    - deploy
+  exclude:
+    - vendor
+  languages:
+    - deploy=sh
+  templates:
+    - templates/page.tmpl
+  gitignore: true
+  prune: true
+  jobs: 4
+`
+
 func supportedLanguagesDesc() string {
-	supportedLanguages := codefiles.SupportedCodeFilenames
-	keys := make([]string, 0, len(supportedLanguages))
-	for k := range supportedLanguages {
-		keys = append(keys, k)
-	}
-	return strings.Join(keys, ", ")
+	return strings.Join(codefiles.SupportedLanguages.Names(), ", ")
 }
 
 // Values from the CLI flags
 var (
-	sourceDir string
-	outputDir string
-	exclude   []string
+	configFile string
+	reportFile string
+	sourceDir  string
+	outputDir  string
+	format     string
+	include    []string
+	exclude    []string
+	languages  []string
+	templates  []string
+	gitignore  bool
+	prune      bool
+	noCache    bool
+	failFast   bool
+	check      bool
+	dryRun     bool
+	watchMode  bool
+	jobs       int
 )
 
 var rootCmd = &cobra.Command{
@@ -54,79 +99,580 @@ var rootCmd = &cobra.Command{
 	Args: cobra.ExactArgs(0),
 
 	Run: func(cmd *cobra.Command, args []string) {
-		excludes, err := getExcludes(cmd)
+		cfg, err := loadConfig(cmd)
+		handleError(err)
+		renderer, err := newRenderer(cfg.Format)
+		handleError(err)
+		templates, err := codefiles.NewPageTemplates(cfg.Templates)
+		handleError(err)
+
+		watchMode, err := cmd.Flags().GetBool("watch")
+		handleError(err)
+		if watchMode {
+			watchDocumentation(cfg, renderer, templates)
+			return
+		}
+
+		failFast, err := cmd.Flags().GetBool("fail-fast")
 		handleError(err)
+		errs := newErrorCollector(failFast)
+		reportFile, err := cmd.Flags().GetString("report")
+		handleError(err)
+		rep := report.New()
+		errs.beforeExit = func() { writeReport(reportFile, rep, errs) }
+
+		sourceCodeFiles := streamCodeFiles(cfg, errs, codeFilePreparer(renderer, templates))
 
-		sourceCodeFiles := findCodeFiles(excludes)
-		sourceCodeFiles = readCodeFiles(sourceCodeFiles)
-		sourceCodeFiles = parseFileContent(sourceCodeFiles)
-		writeDocsFiles(sourceCodeFiles)
+		check, err := cmd.Flags().GetBool("check")
+		handleError(err)
+		if check {
+			outputFiles := generateOutputFiles(sourceCodeFiles, cfg, errs, rep)
+			finish(reportFile, rep, errs)
+			checkOutputFiles(outputFiles, cfg)
+			return
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		handleError(err)
+		if dryRun {
+			outputFiles := generateOutputFiles(sourceCodeFiles, cfg, errs, rep)
+			finish(reportFile, rep, errs)
+			planOutputFiles(outputFiles, cfg)
+			return
+		}
+
+		noCache, err := cmd.Flags().GetBool("no-cache")
+		handleError(err)
+		writeDocumentation(sourceCodeFiles, cfg, templates, !noCache, errs, rep)
+		finish(reportFile, rep, errs)
 	},
 }
 
+// formatJSON exports the parsed documentation of all code files into a single JSON document
+// instead of writing one documentation file per code file.
+const formatJSON = "json"
+
+// newRenderer returns the Renderer for the given output format. The content of the JSON export
+// is rendered as AsciiDoc.
+func newRenderer(format string) (render.Renderer, error) {
+	if format == formatJSON {
+		return render.New(render.FormatAsciiDoc)
+	}
+	return render.New(format)
+}
+
+// loadConfig reads the config file and applies the CLI flags on top of it. Flags which are not
+// explicitly set by the user do not override the values from the config file. The result is
+// validated for generating documentation.
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	cfg, err := readConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// readConfig reads the config file and applies the CLI flags without validating the result.
+func readConfig(cmd *cobra.Command) (*config.Config, error) {
+	file, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(file)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applyFlags(cmd, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyFlags overrides the values of the config with all CLI flags set by the user.
+func applyFlags(cmd *cobra.Command, cfg *config.Config) error {
+	err := applyDirFlags(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	err = applyLayoutFlags(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	err = applyFilterFlags(cmd, cfg)
+	if err != nil {
+		return err
+	}
+	return applyRunFlags(cmd, cfg)
+}
+
+// applyDirFlags overrides the source and output dirs of the config and whether orphaned files
+// are pruned from the output dir.
+func applyDirFlags(cmd *cobra.Command, cfg *config.Config) error {
+	flags := cmd.Flags()
+	if flags.Changed("source-dir") {
+		dir, err := flags.GetString("source-dir")
+		if err != nil {
+			return err
+		}
+		cfg.SourceDirs = []string{dir}
+	}
+	if flags.Changed("output-dir") {
+		dir, err := flags.GetString("output-dir")
+		if err != nil {
+			return err
+		}
+		cfg.OutputDir = dir
+	}
+	if flags.Changed("prune") {
+		enabled, err := flags.GetBool("prune")
+		if err != nil {
+			return err
+		}
+		cfg.Prune = enabled
+	}
+	return nil
+}
+
+// applyLayoutFlags overrides the output format and the page templates of the config.
+func applyLayoutFlags(cmd *cobra.Command, cfg *config.Config) error {
+	flags := cmd.Flags()
+	if flags.Changed("format") {
+		format, err := flags.GetString("format")
+		if err != nil {
+			return err
+		}
+		cfg.Format = format
+	}
+	if flags.Changed("template") {
+		templates, err := flags.GetStringSlice("template")
+		if err != nil {
+			return err
+		}
+		cfg.Templates = templates
+	}
+	return nil
+}
+
+// applyFilterFlags overrides the settings of the config which decide which files are documented.
+func applyFilterFlags(cmd *cobra.Command, cfg *config.Config) error {
+	flags := cmd.Flags()
+	if flags.Changed("include") {
+		includes, err := flags.GetStringSlice("include")
+		if err != nil {
+			return err
+		}
+		cfg.Include = includes
+	}
+	if flags.Changed("exclude") {
+		excludes, err := getExcludes(cmd)
+		if err != nil {
+			return err
+		}
+		cfg.Exclude = excludes
+	}
+	if flags.Changed("language") {
+		mappings, err := flags.GetStringSlice("language")
+		if err != nil {
+			return err
+		}
+		cfg.Languages = mappings
+	}
+	if flags.Changed("gitignore") {
+		enabled, err := flags.GetBool("gitignore")
+		if err != nil {
+			return err
+		}
+		cfg.Gitignore = enabled
+	}
+	return nil
+}
+
+// applyRunFlags overrides the settings of the config which decide how the files are processed.
+func applyRunFlags(cmd *cobra.Command, cfg *config.Config) error {
+	flags := cmd.Flags()
+	if flags.Changed("jobs") {
+		jobs, err := flags.GetInt("jobs")
+		if err != nil {
+			return err
+		}
+		cfg.Jobs = jobs
+	}
+	return nil
+}
+
 func getExcludes(cmd *cobra.Command) ([]string, error) {
 	exclude, err := cmd.Flags().GetStringSlice("exclude")
 	return exclude, err
 }
 
-func findCodeFiles(exclude []string) []*codefiles.CodeFile {
-	finder := codefiles.NewFinder(sourceDir)
-	finder.SetExcludes(exclude)
-	sourceCodeFiles, err := finder.FindSourceCodeFiles()
+// streamCodeFiles searches the code files in all source directories in the background and emits
+// them as soon as they are found, so the code files are processed while the search is still
+// running. Each code file is passed to the prepare function before it is emitted. Missing source
+// directories are invalid settings and end the app. Paths inside the source directories which
+// cannot be inspected are passed to the errorCollector. The channel is closed after the search.
+func streamCodeFiles(cfg *config.Config, errs *errorCollector, prepare func(file *codefiles.CodeFile)) <-chan *codefiles.CodeFile {
+	for _, dir := range cfg.SourceDirs {
+		_, err := os.Stat(dir)
+		if err != nil {
+			handleError(fmt.Errorf("invalid source dir: %v", err))
+		}
+	}
 
-	if err != nil {
-		handleError(err)
+	files := make(chan *codefiles.CodeFile)
+	go func() {
+		defer close(files)
+		for _, dir := range cfg.SourceDirs {
+			finder := codefiles.NewFinder(dir)
+			finder.SetIncludes(cfg.Include)
+			finder.SetExcludes(cfg.Exclude)
+			finder.SetLanguageMappings(cfg.Languages)
+			finder.SetGitignore(cfg.Gitignore)
+			err := finder.WalkSourceCodeFiles(func(file *codefiles.CodeFile) {
+				prepare(file)
+				files <- file
+			})
+			errs.add(stageDiscovery, err)
+		}
+	}()
+	return files
+}
+
+// findCodeFiles finds the code files in all source directories and returns them once the search
+// is complete (see streamCodeFiles). The code files are not prepared for parsing.
+func findCodeFiles(cfg *config.Config, errs *errorCollector) []*codefiles.CodeFile {
+	sourceCodeFiles := []*codefiles.CodeFile{}
+	for file := range streamCodeFiles(cfg, errs, func(file *codefiles.CodeFile) {}) {
+		sourceCodeFiles = append(sourceCodeFiles, file)
 	}
 	return sourceCodeFiles
 }
 
-// readCodeFiles reads the code files from the source directory.
-func readCodeFiles(files []*codefiles.CodeFile) []*codefiles.CodeFile {
+// codeFilePreparer returns a function which prepares a code file for parsing. The documentation
+// is rendered in the output format of the given renderer and with the page template for the
+// language of the code file.
+func codeFilePreparer(renderer render.Renderer, templates *codefiles.PageTemplates) func(file *codefiles.CodeFile) {
+	return func(file *codefiles.CodeFile) {
+		file.SetRenderer(renderer)
+		file.SetPageTemplate(templates.ForLanguage(file.Language()))
+	}
+}
+
+// prepareCodeFiles prepares all given code files for parsing (see codeFilePreparer).
+func prepareCodeFiles(files []*codefiles.CodeFile, renderer render.Renderer, templates *codefiles.PageTemplates) {
+	prepare := codeFilePreparer(renderer, templates)
 	for _, file := range files {
-		err := file.ReadFileContent()
+		prepare(file)
+	}
+}
+
+// fileResult is the result of processing a single code file. If processing failed, the stage
+// and the error describe the failure. If the code file was skipped because it did not change since
+// the previous run, the documentation file is nil and only the path of the documentation file is known.
+type fileResult struct {
+	codeFile *codefiles.CodeFile
+	file     *output.File
+	output   string
+	hash     string
+	status   report.Status
+	stage    stage
+	err      error
+}
+
+// failed returns a fileResult describing the failure of the code file in the given stage.
+func failed(file *codefiles.CodeFile, stage stage, err error) *fileResult {
+	return &fileResult{codeFile: file, status: report.StatusFailed, stage: stage, err: fmt.Errorf("%s: %v", file.SourcePath(), err)}
+}
+
+// reportEntry describes the fileResult for the run report.
+func (result *fileResult) reportEntry() report.Entry {
+	entry := report.Entry{
+		Source:   result.codeFile.SourcePath(),
+		Language: result.codeFile.Language(),
+		Output:   result.output,
+		Status:   result.status,
+		Parts:    len(result.codeFile.DocumentationParts()),
+	}
+	if result.file != nil {
+		entry.Output = result.file.Path
+	}
+	if result.status != report.StatusFailed {
+		entry.Warnings = result.codeFile.Warnings()
+	}
+	if result.err != nil {
+		entry.Errors = []string{result.err.Error()}
+	}
+	return entry
+}
+
+// processCodeFiles runs the work function in parallel for all code files as soon as they are
+// received and returns the results of all successfully processed code files in the order of the
+// code files. Failures are passed to the errorCollector. All code files are added to the report.
+func processCodeFiles(files <-chan *codefiles.CodeFile, cfg *config.Config, errs *errorCollector, rep *report.Report, work func(file *codefiles.CodeFile) *fileResult) []*fileResult {
+	results := []*fileResult{}
+	err := pipeline.Process(files, cfg.Jobs, func(file *codefiles.CodeFile) (*fileResult, error) {
+		return work(file), nil
+	}, func(result *fileResult) error {
+		rep.Add(result.reportEntry())
+		if result.err != nil {
+			errs.add(result.stage, result.err)
+			return nil
+		}
+		results = append(results, result)
+		return nil
+	})
+	handleError(err)
+	return results
+}
+
+// parseCodeFile reads the code file from the source directory and parses its content for
+// comments.
+func parseCodeFile(file *codefiles.CodeFile) *fileResult {
+	err := file.ReadFileContent()
+	if err != nil {
+		return failed(file, stageParse, err)
+	}
+	err = file.Parse()
+	if err != nil {
+		return failed(file, stageParse, err)
+	}
+	return &fileResult{codeFile: file, status: report.StatusParsed}
+}
+
+// generateCodeFile reads and parses the code file and creates its documentation file in memory.
+func generateCodeFile(file *codefiles.CodeFile, cfg *config.Config) *fileResult {
+	result := parseCodeFile(file)
+	if result.err != nil {
+		return result
+	}
+	result.file, result.err = file.DocumentationFile(cfg.OutputDir)
+	if result.err != nil {
+		return failed(file, stageParse, result.err)
+	}
+	result.status = report.StatusGenerated
+	return result
+}
+
+// generateOutputFiles reads and parses the code files and creates the documentation files in
+// memory. The code files are processed in parallel, the documentation files are returned in the
+// order of the code files. Code files which cannot be processed are passed to the errorCollector
+// and have no documentation file. For the JSON format, a single export file is created instead.
+func generateOutputFiles(files <-chan *codefiles.CodeFile, cfg *config.Config, errs *errorCollector, rep *report.Report) []*output.File {
+	if cfg.Format == formatJSON {
+		parsedFiles := []*codefiles.CodeFile{}
+		for _, result := range processCodeFiles(files, cfg, errs, rep, parseCodeFile) {
+			parsedFiles = append(parsedFiles, result.codeFile)
+		}
+		exportFile, err := codefiles.NewExport(parsedFiles).ExportFile(cfg.OutputDir)
 		handleError(err)
+		return []*output.File{exportFile}
 	}
-	return files
+
+	outputFiles := []*output.File{}
+	results := processCodeFiles(files, cfg, errs, rep, func(file *codefiles.CodeFile) *fileResult {
+		return generateCodeFile(file, cfg)
+	})
+	for _, result := range results {
+		outputFiles = append(outputFiles, result.file)
+	}
+	return outputFiles
 }
 
-// parseFileContent parses the content of the code files for comments.
-func parseFileContent(files []*codefiles.CodeFile) []*codefiles.CodeFile {
-	for _, file := range files {
-		err := file.Parse()
+// writeDocumentation generates the documentation files and writes them to the output directory.
+// The code files are processed in parallel, the written files are logged in the order of the code
+// files. The documentation files are recorded in the manifest of the output directory together
+// with the hash of their code file. If the cache is used, code files which did not change since
+// the previous run are parsed but not written. The JSON export is neither cached nor recorded.
+// Code files which cannot be processed are passed to the errorCollector.
+func writeDocumentation(files <-chan *codefiles.CodeFile, cfg *config.Config, templates *codefiles.PageTemplates, useCache bool, errs *errorCollector, rep *report.Report) {
+	if cfg.Format == formatJSON {
+		writeOutputFiles(generateOutputFiles(files, cfg, errs, rep), errs)
+		return
+	}
+
+	manifest, err := output.LoadManifest(cfg.OutputDir)
+	handleError(err)
+	manifest.UseSettings(Version, settingsHash(cfg, templates))
+
+	writeCodeFiles(files, cfg, manifest, useCache, errs, rep)
+	if cfg.Prune {
+		err = manifest.Prune()
 		handleError(err)
 	}
-	return files
+	err = manifest.Write()
+	handleError(err)
 }
 
-// writeDocsFiles writes the documentation files to the output directory.
-func writeDocsFiles(files []*codefiles.CodeFile) {
-	for _, file := range files {
-		err := file.WriteDocumentationFile(outputDir)
+// writeCodeFiles writes the documentation files of the given code files and records them in the
+// manifest. The manifest itself is not written.
+func writeCodeFiles(files <-chan *codefiles.CodeFile, cfg *config.Config, manifest *output.Manifest, useCache bool, errs *errorCollector, rep *report.Report) {
+	written := []*fileResult{}
+	results := processCodeFiles(files, cfg, errs, rep, func(file *codefiles.CodeFile) *fileResult {
+		return writeCodeFile(file, cfg, manifest, useCache)
+	})
+	for _, result := range results {
+		if result.file != nil {
+			fmt.Println(result.file)
+			written = append(written, result)
+		}
+	}
+	if skipped := len(results) - len(written); skipped > 0 {
+		fmt.Printf("%d unchanged code files skipped\n", skipped)
+	}
+
+	for _, result := range written {
+		err := manifest.Add(result.file, result.hash)
 		handleError(err)
 	}
 }
 
+// writeCodeFile reads and parses a single code file and writes its documentation file. If the
+// cache is used and the code file did not change since the previous run according to the
+// manifest, the documentation file is not written. The code file is parsed anyway, so the report
+// contains its parts and warnings (parsing is cheap compared to writing). Code files whose
+// documentation file was removed are treated as changed.
+func writeCodeFile(file *codefiles.CodeFile, cfg *config.Config, manifest *output.Manifest, useCache bool) *fileResult {
+	result := parseCodeFile(file)
+	if result.err != nil {
+		return result
+	}
+	path := file.DocumentationPath(cfg.OutputDir)
+	if useCache && manifest.IsUnchanged(path, file.SourcePath(), file.DocumentationHash()) {
+		result.output = path
+		result.status = report.StatusUnchanged
+		return result
+	}
+
+	result.file, result.err = file.DocumentationFile(cfg.OutputDir)
+	if result.err != nil {
+		return failed(file, stageParse, result.err)
+	}
+	result.hash = file.DocumentationHash()
+	err := result.file.Write()
+	if err != nil {
+		return failed(file, stageWrite, err)
+	}
+	result.status = report.StatusWritten
+	return result
+}
+
+// settingsHash returns a hash of all settings which influence the content of all documentation
+// files: the output format and the page templates. References between code files only influence
+// the documentation files of the referencing code files and are covered by their own hashes (see
+// CodeFile.DocumentationHash).
+func settingsHash(cfg *config.Config, templates *codefiles.PageTemplates) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", cfg.Format, templates.Hash())
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// writeOutputFiles writes the documentation files to the output directory. Files which cannot be
+// written are passed to the errorCollector.
+func writeOutputFiles(files []*output.File, errs *errorCollector) {
+	for _, file := range files {
+		err := file.Write()
+		if err != nil {
+			errs.add(stageWrite, fmt.Errorf("%s: %v", file.Path, err))
+			continue
+		}
+		fmt.Println(file)
+	}
+}
+
+// finish reports all collected errors (which ends the app) and writes the report file.
+func finish(reportFile string, rep *report.Report, errs *errorCollector) {
+	errs.report()
+	writeReport(reportFile, rep, errs)
+}
+
+// writeReport writes the report of the run to the given file. Errors which are not related to a
+// single code file are taken from the errorCollector. Without file, nothing is written.
+func writeReport(reportFile string, rep *report.Report, errs *errorCollector) {
+	if reportFile == "" {
+		return
+	}
+	for _, stageErr := range errs.collected() {
+		if stageErr.stage == stageDiscovery {
+			rep.AddError(stageErr.err)
+		}
+	}
+	err := rep.Write(reportFile)
+	handleError(err)
+}
+
+// planOutputFiles reports which documentation files would be created, updated, deleted or left
+// unchanged without writing anything. Orphaned files are only deleted when pruning is enabled.
+func planOutputFiles(files []*output.File, cfg *config.Config) {
+	plan, err := output.NewPlan(files)
+	handleError(err)
+	if cfg.Prune {
+		addOrphans(plan, cfg)
+	}
+	plan.WriteDryRunReport(os.Stdout)
+}
+
+// checkOutputFiles compares the documentation files with the output directory without writing
+// anything. Missing, stale and orphaned files are reported and result in an error.
+func checkOutputFiles(files []*output.File, cfg *config.Config) {
+	plan, err := output.NewPlan(files)
+	handleError(err)
+	addOrphans(plan, cfg)
+
+	plan.WriteCheckReport(os.Stdout)
+	if !plan.IsUpToDate() {
+		handleError(fmt.Errorf("documentation in %s is out of date: run source2adoc without --check to update it", cfg.OutputDir))
+	}
+	fmt.Println("documentation in " + cfg.OutputDir + " is up to date")
+}
+
+// addOrphans adds the files from the manifest of the output directory whose source no longer
+// exists to the plan. Hand-written files are not part of the manifest and are never orphaned.
+// Without manifest (e.g. if the manifest was not committed), all generated files in the output
+// directory which are not part of the plan are orphans. Generated files are recognized by their
+// header (see output.GeneratedFiles).
+func addOrphans(plan *output.Plan, cfg *config.Config) {
+	if cfg.Format == formatJSON {
+		return
+	}
+	manifest, err := output.LoadManifest(cfg.OutputDir)
+	handleError(err)
+	if len(manifest.Files) > 0 {
+		plan.AddOrphans(manifest.Orphans())
+		return
+	}
+	generated, err := output.GeneratedFiles(cfg.OutputDir)
+	handleError(err)
+	plan.AddOrphans(generated)
+}
+
 func init() {
-	initMandatoryFlags()
+	initSingleValueFlags()
 	initMultipleValuesFlags()
+	initBoolFlags()
+	initIntFlags()
+	rootCmd.Version = toolVersion()
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
 }
 
-func initMandatoryFlags() {
+func initSingleValueFlags() {
 	var params = []struct {
 		name     string
 		short    string
 		variable *string
+		value    string
 		desc     string
 	}{
 		{name: "source-dir", short: "s", variable: &sourceDir, desc: "Directory containing the source code files"},
 		{name: "output-dir", short: "o", variable: &outputDir, desc: "Directory to write the generated documentation to"},
+		{name: "config", short: "c", variable: &configFile, desc: "Config file (defaults to " + config.DefaultFilename + " in the working directory)"},
+		{name: "report", short: "r", variable: &reportFile, desc: "Write a report of all processed code files to this file (JUnit XML for .xml files, JSON otherwise)"},
+		{name: "format", short: "f", variable: &format, value: render.FormatAsciiDoc, desc: "Output format of the documentation (" + strings.Join(append(render.Formats(), formatJSON), ", ") + ")"},
 	}
 
 	for _, param := range params {
-		rootCmd.Flags().StringVarP(param.variable, param.name, param.short, "", param.desc)
-		err := rootCmd.MarkFlagRequired(param.name)
-		handleError(err)
+		rootCmd.Flags().StringVarP(param.variable, param.name, param.short, param.value, param.desc)
 	}
 }
 
@@ -139,7 +685,10 @@ func initMultipleValuesFlags() {
 		desc      string
 		mandatory bool
 	}{
+		{name: "include", short: "i", variable: &include, desc: "Only include files and/or folders matching these patterns when generating documentation"},
 		{name: "exclude", short: "x", variable: &exclude, desc: "Exclude files and/or folders when generating documentation"},
+		{name: "language", short: "l", variable: &languages, desc: "Assign a language to files matching a pattern (e.g. bin/deploy=sh)"},
+		{name: "template", short: "t", variable: &templates, desc: "Go template for the page layout, either for all languages or per language (e.g. sh=templates/script.tmpl)"},
 	}
 
 	for _, param := range params {
@@ -147,6 +696,77 @@ func initMultipleValuesFlags() {
 	}
 }
 
+func initBoolFlags() {
+	var params = []struct {
+		name     string
+		variable *bool
+		desc     string
+	}{
+		{name: "gitignore", variable: &gitignore, desc: "Respect .gitignore files (including those above the source dir up to the root of the git repository) when searching for source code files"},
+		{name: "prune", variable: &prune, desc: "Delete previously generated documentation files whose source code file no longer exists"},
+		{name: "fail-fast", variable: &failFast, desc: "Stop at the first code file which cannot be processed instead of reporting all errors at the end"},
+		{name: "no-cache", variable: &noCache, desc: "Regenerate all documentation files, even if their source code file did not change since the previous run"},
+		{name: "check", variable: &check, desc: "Fail if the documentation in the output directory is out of date (nothing is written)"},
+		{name: "dry-run", variable: &dryRun, desc: "Report which documentation files would be created, updated, deleted or left unchanged (nothing is written)"},
+		{name: "watch", variable: &watchMode, desc: "Keep running and update the documentation whenever code files in the source dirs change"},
+	}
+
+	for _, param := range params {
+		rootCmd.Flags().BoolVar(param.variable, param.name, false, param.desc)
+	}
+	rootCmd.MarkFlagsMutuallyExclusive("check", "dry-run", "watch")
+	rootCmd.MarkFlagsMutuallyExclusive("watch", "report")
+	rootCmd.MarkFlagsMutuallyExclusive("watch", "fail-fast")
+}
+
+func initIntFlags() {
+	var params = []struct {
+		name     string
+		short    string
+		variable *int
+		value    int
+		desc     string
+	}{
+		{name: "jobs", short: "j", variable: &jobs, value: runtime.NumCPU(), desc: "Number of code files which are processed in parallel"},
+	}
+
+	for _, param := range params {
+		rootCmd.Flags().IntVarP(param.variable, param.name, param.short, param.value, param.desc)
+	}
+}
+
+// addSourceFlags adds the flags deciding which code files are found to a subcommand which only
+// reads the code files. The flags are read through applyFlags, just like the flags of the root
+// command.
+func addSourceFlags(cmd *cobra.Command) {
+	var stringParams = []struct {
+		name  string
+		short string
+		desc  string
+	}{
+		{name: "source-dir", short: "s", desc: "Directory containing the source code files"},
+		{name: "config", short: "c", desc: "Config file (defaults to " + config.DefaultFilename + " in the working directory)"},
+	}
+	for _, param := range stringParams {
+		cmd.Flags().StringP(param.name, param.short, "", param.desc)
+	}
+
+	var sliceParams = []struct {
+		name  string
+		short string
+		desc  string
+	}{
+		{name: "include", short: "i", desc: "Only include files and/or folders matching these patterns"},
+		{name: "exclude", short: "x", desc: "Exclude files and/or folders"},
+		{name: "language", short: "l", desc: "Assign a language to files matching a pattern (e.g. bin/deploy=sh)"},
+	}
+	for _, param := range sliceParams {
+		cmd.Flags().StringSliceP(param.name, param.short, []string{}, param.desc)
+	}
+
+	cmd.Flags().Bool("gitignore", false, "Respect .gitignore files (including those above the source dir up to the root of the git repository) when searching for source code files")
+}
+
 // Execute acts as the entrypoint for the CLI app.
 func Execute() {
 	err := rootCmd.Execute()
@@ -1,8 +1,17 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/sommerfeld-io/source2adoc/internal/codefiles"
+	"github.com/sommerfeld-io/source2adoc/internal/config"
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+	"github.com/sommerfeld-io/source2adoc/internal/pipeline"
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+	"github.com/sommerfeld-io/source2adoc/internal/report"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -17,7 +26,21 @@ func Test_RootCmd(t *testing.T) {
 	flags := cmd.Flags()
 	assert.NotNil(flags.Lookup("source-dir"), "Missing --source-dir flag")
 	assert.NotNil(flags.Lookup("output-dir"), "Missing --output-dir flag")
+	assert.NotNil(flags.Lookup("include"), "Missing --include flag")
 	assert.NotNil(flags.Lookup("exclude"), "Missing --exclude flag")
+	assert.NotNil(flags.Lookup("language"), "Missing --language flag")
+	assert.NotNil(flags.Lookup("template"), "Missing --template flag")
+	assert.NotNil(flags.Lookup("config"), "Missing --config flag")
+	assert.NotNil(flags.Lookup("format"), "Missing --format flag")
+	assert.NotNil(flags.Lookup("gitignore"), "Missing --gitignore flag")
+	assert.NotNil(flags.Lookup("prune"), "Missing --prune flag")
+	assert.NotNil(flags.Lookup("no-cache"), "Missing --no-cache flag")
+	assert.NotNil(flags.Lookup("jobs"), "Missing --jobs flag")
+	assert.NotNil(flags.Lookup("fail-fast"), "Missing --fail-fast flag")
+	assert.NotNil(flags.Lookup("report"), "Missing --report flag")
+	assert.NotNil(flags.Lookup("check"), "Missing --check flag")
+	assert.NotNil(flags.Lookup("dry-run"), "Missing --dry-run flag")
+	assert.NotNil(flags.Lookup("watch"), "Missing --watch flag")
 }
 
 func Test_ShouldGetExcludes(t *testing.T) {
@@ -41,3 +64,305 @@ func Test_ShouldGetExcludes(t *testing.T) {
 		assert.Equal(test.expected, excludes, "Incorrect excludes")
 	}
 }
+
+func Test_ShouldOverrideConfigWithFlags(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("source-dir", "", "")
+	cmd.Flags().String("output-dir", "", "")
+	cmd.Flags().String("format", "", "")
+	cmd.Flags().StringSlice("include", []string{}, "")
+	cmd.Flags().StringSlice("exclude", []string{}, "")
+	cmd.Flags().StringSlice("language", []string{}, "")
+	cmd.Flags().StringSlice("template", []string{}, "")
+	cmd.Flags().Bool("gitignore", false, "")
+	cmd.Flags().Bool("prune", false, "")
+	cmd.Flags().Int("jobs", 1, "")
+	err := cmd.Flags().Parse([]string{"--output-dir", "flag/docs", "--format", "markdown", "--exclude", "flag/vendor", "--language", "flag/bin/*=sh", "--template", "sh=flag/script.tmpl", "--gitignore", "--prune", "--jobs", "2"})
+	assert.Nil(err, "Error parsing flags")
+
+	cfg := config.New()
+	cfg.SourceDirs = []string{"config/src"}
+	cfg.OutputDir = "config/docs"
+	cfg.Include = []string{"config/src"}
+	cfg.Exclude = []string{"config/vendor"}
+
+	err = applyFlags(cmd, cfg)
+	assert.Nil(err, "Error applying flags")
+
+	assert.Equal([]string{"config/src"}, cfg.SourceDirs, "Source dirs should be taken from the config")
+	assert.Equal("flag/docs", cfg.OutputDir, "Output dir should be overridden by flag")
+	assert.Equal("markdown", cfg.Format, "Format should be overridden by flag")
+	assert.Equal([]string{"config/src"}, cfg.Include, "Includes should be taken from the config")
+	assert.Equal([]string{"flag/vendor"}, cfg.Exclude, "Excludes should be overridden by flag")
+	assert.Equal([]string{"flag/bin/*=sh"}, cfg.Languages, "Language mappings should be overridden by flag")
+	assert.Equal([]string{"sh=flag/script.tmpl"}, cfg.Templates, "Templates should be overridden by flag")
+	assert.True(cfg.Gitignore, "Gitignore should be overridden by flag")
+	assert.True(cfg.Prune, "Prune should be overridden by flag")
+	assert.Equal(2, cfg.Jobs, "Jobs should be overridden by flag")
+}
+
+func Test_ShouldCreateRendererForFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: render.FormatAsciiDoc, expected: render.FormatAsciiDoc},
+		{format: render.FormatMarkdown, expected: render.FormatMarkdown},
+		{format: formatJSON, expected: render.FormatAsciiDoc},
+	}
+
+	for _, test := range tests {
+		renderer, err := newRenderer(test.format)
+		assert.Nil(err, "Error creating renderer")
+		assert.Equal(test.expected, renderer.Format(), "Incorrect renderer for "+test.format)
+	}
+
+	_, err := newRenderer("html")
+	assert.NotNil(err, "Unsupported format should be reported")
+}
+
+func Test_ShouldHashSettings(t *testing.T) {
+	assert := assert.New(t)
+
+	templates, err := codefiles.NewPageTemplates([]string{})
+	assert.Nil(err, "Error reading templates")
+	asciidoc := config.New()
+	markdown := config.New()
+	markdown.Format = render.FormatMarkdown
+
+	assert.Equal(settingsHash(asciidoc, templates), settingsHash(asciidoc, templates), "Same settings should result in the same hash")
+	assert.NotEqual(settingsHash(asciidoc, templates), settingsHash(markdown, templates), "Format should change the hash")
+}
+
+// chdir changes the working directory for the duration of the test.
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err, "Error getting working directory")
+	err = os.Chdir(dir)
+	assert.Nil(t, err, "Error changing working directory")
+	t.Cleanup(func() {
+		err := os.Chdir(wd)
+		assert.Nil(t, err, "Error restoring working directory")
+	})
+}
+
+func Test_ShouldKeepDocsOfWorkingDirWhenPruning(t *testing.T) {
+	assert := assert.New(t)
+
+	chdir(t, t.TempDir())
+	err := os.WriteFile("run.sh", []byte("## Run the app\n"), 0644)
+	assert.Nil(err, "Error creating code file")
+
+	cfg := config.New()
+	cfg.SourceDirs = []string{"."}
+	cfg.OutputDir = "docs"
+	cfg.Prune = true
+	templates, err := codefiles.NewPageTemplates([]string{})
+	assert.Nil(err, "Error reading templates")
+
+	errs, _, exitCodes := newTestErrorCollector(false)
+	files := findCodeFiles(cfg, errs)
+	prepareCodeFiles(files, &render.AsciiDoc{}, templates)
+	writeDocumentation(pipeline.Items(files), cfg, templates, true, errs, report.New())
+	errs.report()
+
+	assert.Empty(*exitCodes, "No errors expected")
+	assert.FileExists(filepath.Join("docs", "run-sh.adoc"), "Documentation file should be kept")
+	manifest, err := output.LoadManifest("docs")
+	assert.Nil(err, "Error loading manifest")
+	assert.Equal(map[string]output.ManifestEntry{"run-sh.adoc": {Source: "../run.sh", Hash: files[0].DocumentationHash()}}, manifest.Files, "Incorrect manifest")
+}
+
+func Test_ShouldKeepDocsWhenRunFromOtherWorkingDir(t *testing.T) {
+	assert := assert.New(t)
+
+	root := t.TempDir()
+	err := os.MkdirAll(filepath.Join(root, "repo", "src"), 0755)
+	assert.Nil(err, "Error creating source dir")
+	err = os.WriteFile(filepath.Join(root, "repo", "src", "run.sh"), []byte("## Run the app\n"), 0644)
+	assert.Nil(err, "Error creating code file")
+	templates, err := codefiles.NewPageTemplates([]string{})
+	assert.Nil(err, "Error reading templates")
+
+	for _, wd := range []string{filepath.Join(root, "repo"), root} {
+		chdir(t, wd)
+		prefix, err := filepath.Rel(wd, filepath.Join(root, "repo"))
+		assert.Nil(err, "Error resolving repo")
+		cfg := config.New()
+		cfg.SourceDirs = []string{filepath.Join(prefix, "src")}
+		cfg.OutputDir = filepath.Join(prefix, "docs")
+		cfg.Prune = true
+
+		errs, _, exitCodes := newTestErrorCollector(false)
+		rep := report.New()
+		writeDocumentation(streamCodeFiles(cfg, errs, codeFilePreparer(&render.AsciiDoc{}, templates)), cfg, templates, true, errs, rep)
+		errs.report()
+		assert.Empty(*exitCodes, "No errors expected")
+		assert.Equal(1, len(rep.Files), "Code file should be found from "+wd)
+	}
+
+	assert.FileExists(filepath.Join(root, "repo", "docs", "src", "run-sh.adoc"), "Documentation file should be kept")
+	manifest, err := output.LoadManifest(filepath.Join(root, "repo", "docs"))
+	assert.Nil(err, "Error loading manifest")
+	assert.Empty(manifest.Orphans(), "Documentation file should not be orphaned")
+}
+
+func Test_ShouldFindCodeFilesWithConfigExample(t *testing.T) {
+	assert := assert.New(t)
+
+	chdir(t, t.TempDir())
+	for _, path := range []string{"src/script.sh", "src/vendor/lib.sh", "src/Dockerfile", "bin/deploy"} {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		assert.Nil(err, "Error creating directory")
+		err = os.WriteFile(path, []byte("## Code file\n"), 0644)
+		assert.Nil(err, "Error creating code file")
+	}
+
+	cfg, err := config.Parse([]byte(configExample))
+	assert.Nil(err, "Error parsing config example")
+
+	errs, _, exitCodes := newTestErrorCollector(false)
+	files := findCodeFiles(cfg, errs)
+	errs.report()
+
+	assert.Empty(*exitCodes, "No errors expected")
+	expected := []*codefiles.CodeFile{
+		codefiles.NewCodeFile("src/script.sh"),
+		codefiles.NewCodeFileWithLanguage("bin/deploy", codefiles.LanguageBash),
+	}
+	assert.Equal(expected, files, "Incorrect code files")
+}
+
+func Test_ShouldNotCheckHandWrittenPagesAsOrphans(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, withManifest := range []bool{true, false} {
+		chdir(t, t.TempDir())
+		err := os.WriteFile("run.sh", []byte("## Run the app\n"), 0644)
+		assert.Nil(err, "Error creating code file")
+
+		cfg := config.New()
+		cfg.SourceDirs = []string{"."}
+		cfg.OutputDir = "docs"
+		templates, err := codefiles.NewPageTemplates([]string{})
+		assert.Nil(err, "Error reading templates")
+
+		errs, _, exitCodes := newTestErrorCollector(false)
+		writeDocumentation(streamCodeFiles(cfg, errs, codeFilePreparer(&render.AsciiDoc{}, templates)), cfg, templates, true, errs, report.New())
+		err = os.WriteFile(filepath.Join("docs", "guide.adoc"), []byte("= Hand-written guide\n"), 0644)
+		assert.Nil(err, "Error creating hand-written page")
+		if !withManifest {
+			err = os.Remove(filepath.Join("docs", output.ManifestFilename))
+			assert.Nil(err, "Error deleting manifest")
+		}
+
+		plan, err := output.NewPlan(generateOutputFiles(streamCodeFiles(cfg, errs, codeFilePreparer(&render.AsciiDoc{}, templates)), cfg, errs, report.New()))
+		assert.Nil(err, "Error creating plan")
+		addOrphans(plan, cfg)
+		assert.True(plan.IsUpToDate(), fmt.Sprintf("Hand-written pages should not be orphans (manifest: %t)", withManifest))
+
+		err = os.Remove("run.sh")
+		assert.Nil(err, "Error deleting code file")
+		plan, err = output.NewPlan(generateOutputFiles(streamCodeFiles(cfg, errs, codeFilePreparer(&render.AsciiDoc{}, templates)), cfg, errs, report.New()))
+		assert.Nil(err, "Error creating plan")
+		addOrphans(plan, cfg)
+		errs.report()
+
+		assert.Empty(*exitCodes, "No errors expected")
+		expected := []output.Change{{File: &output.File{Path: filepath.Join("docs", "run-sh.adoc")}, Status: output.StatusOrphaned}}
+		assert.Equal(expected, plan.OutOfDate(), fmt.Sprintf("Only generated pages should be orphans (manifest: %t)", withManifest))
+	}
+}
+
+func Test_ShouldReportPartsAndWarningsOfUnchangedFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	chdir(t, t.TempDir())
+	err := os.WriteFile("run.sh", []byte("## Run the app\n\n## Greet someone.\ngreet() {\n}\n"), 0644)
+	assert.Nil(err, "Error creating code file")
+	err = os.WriteFile("undocumented.sh", []byte("echo hello\n"), 0644)
+	assert.Nil(err, "Error creating code file")
+
+	cfg := config.New()
+	cfg.SourceDirs = []string{"."}
+	cfg.OutputDir = "docs"
+	templates, err := codefiles.NewPageTemplates([]string{})
+	assert.Nil(err, "Error reading templates")
+
+	reports := []*report.Report{}
+	for range 2 {
+		errs, _, exitCodes := newTestErrorCollector(false)
+		files := streamCodeFiles(cfg, errs, codeFilePreparer(&render.AsciiDoc{}, templates))
+		rep := report.New()
+		writeDocumentation(files, cfg, templates, true, errs, rep)
+		errs.report()
+		assert.Empty(*exitCodes, "No errors expected")
+		reports = append(reports, rep)
+	}
+
+	for i := range reports[0].Files {
+		written, unchanged := reports[0].Files[i], reports[1].Files[i]
+		assert.Equal(report.StatusWritten, written.Status, "First run should write "+written.Source)
+		assert.Equal(report.StatusUnchanged, unchanged.Status, "Second run should skip "+unchanged.Source)
+		assert.Equal(written.Parts, unchanged.Parts, "Incorrect parts of "+unchanged.Source)
+		assert.Equal(written.Warnings, unchanged.Warnings, "Incorrect warnings of "+unchanged.Source)
+	}
+	assert.NotEmpty(reports[1].Files[1].Warnings, "Warnings of unchanged files should be reported")
+}
+
+func Test_ShouldOnlyRewriteReferencingFilesWhenCodeFilesAreAdded(t *testing.T) {
+	assert := assert.New(t)
+
+	chdir(t, t.TempDir())
+	cfg := config.New()
+	cfg.SourceDirs = []string{"src"}
+	cfg.OutputDir = "docs"
+	templates, err := codefiles.NewPageTemplates([]string{})
+	assert.Nil(err, "Error reading templates")
+
+	tests := []struct {
+		add      string
+		content  string
+		expected map[string]report.Status
+	}{
+		{
+			add:      "src/run.sh",
+			content:  "## Docs\n## @see src/lib.sh\n",
+			expected: map[string]report.Status{"src/run.sh": report.StatusWritten},
+		},
+		{
+			add:      "src/other.sh",
+			content:  "## Docs\n",
+			expected: map[string]report.Status{"src/other.sh": report.StatusWritten, "src/run.sh": report.StatusUnchanged},
+		},
+		{
+			add:      "src/lib.sh",
+			content:  "## Docs\n",
+			expected: map[string]report.Status{"src/lib.sh": report.StatusWritten, "src/other.sh": report.StatusUnchanged, "src/run.sh": report.StatusWritten},
+		},
+	}
+
+	for _, test := range tests {
+		err := os.MkdirAll("src", 0755)
+		assert.Nil(err, "Error creating directory")
+		err = os.WriteFile(test.add, []byte(test.content), 0644)
+		assert.Nil(err, "Error creating code file")
+
+		errs, _, exitCodes := newTestErrorCollector(false)
+		files := streamCodeFiles(cfg, errs, codeFilePreparer(&render.AsciiDoc{}, templates))
+		rep := report.New()
+		writeDocumentation(files, cfg, templates, true, errs, rep)
+		errs.report()
+		assert.Empty(*exitCodes, "No errors expected")
+
+		statuses := map[string]report.Status{}
+		for _, entry := range rep.Files {
+			statuses[entry.Source] = entry.Status
+		}
+		assert.Equal(test.expected, statuses, "Incorrect statuses after adding "+test.add)
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/sommerfeld-io/source2adoc/internal/codefiles"
+	"github.com/sommerfeld-io/source2adoc/internal/config"
+	"github.com/sommerfeld-io/source2adoc/internal/preview"
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+	"github.com/sommerfeld-io/source2adoc/internal/report"
+	"github.com/sommerfeld-io/source2adoc/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+const serveDescShort = "Preview the documentation in the browser without writing anything."
+const serveDescLong = `
+Generates the documentation of all code files in the --source-dir in memory
+and serves the pages as HTML on localhost. The serve command uses the same
+settings as the root command (config file, includes, excludes, language
+mappings, templates and ignore files). The output dir is not needed.
+
+The HTML rendering is a simple preview to check the inline comments. It
+supports the AsciiDoc syntax written by source2adoc and common markup from
+the comments, but it is no replacement for Antora.
+
+Whenever code files change, the documentation is generated again and all
+pages which are open in a browser reload themselves.
+
+Example:
+  source2adoc serve --source-dir ./src --port 8080
+
+Example (Docker):
+  docker run -v "$(pwd):$(pwd)" -w "$(pwd)" -p 8080:8080 sommerfeldio/source2adoc:latest serve -s ./src --host 0.0.0.0
+`
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: serveDescShort,
+	Long:  serveDescLong,
+
+	Args: cobra.ExactArgs(0),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := loadServeConfig(cmd)
+		handleError(err)
+		templates, err := codefiles.NewPageTemplates(cfg.Templates)
+		handleError(err)
+
+		host, err := cmd.Flags().GetString("host")
+		handleError(err)
+		port, err := cmd.Flags().GetInt("port")
+		handleError(err)
+
+		site := preview.NewSite()
+		generatePreview(site, cfg, templates)
+
+		watcher, err := watch.New(cfg.SourceDirs, []string{}, watchDelay)
+		handleError(err)
+		defer watcher.Close()
+		go func() {
+			err := watcher.Run(nil, func(changed []string) {
+				generatePreview(site, cfg, templates)
+			})
+			handleError(err)
+		}()
+
+		address := fmt.Sprintf("%s:%d", host, port)
+		fmt.Println("serving documentation on http://" + address + " (press Ctrl+C to stop)")
+		err = http.ListenAndServe(address, site)
+		handleError(err)
+	},
+}
+
+// loadServeConfig reads the config file and applies the CLI flags of the serve command on top of
+// it. The documentation is always generated as AsciiDoc, because the preview renders AsciiDoc. The
+// pages are kept in memory, so they are generated relative to the root of the preview.
+func loadServeConfig(cmd *cobra.Command) (*config.Config, error) {
+	cfg, err := readConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Format = render.FormatAsciiDoc
+	cfg.OutputDir = "."
+	return cfg, cfg.ValidateSources()
+}
+
+// generatePreview generates the documentation of all code files in memory and replaces the pages
+// of the site. Code files which cannot be processed are reported without ending the app, so the
+// preview keeps running until the code files are fixed.
+func generatePreview(site *preview.Site, cfg *config.Config, templates *codefiles.PageTemplates) {
+	errs := newWatchErrorCollector()
+	files := streamCodeFiles(cfg, errs, codeFilePreparer(&render.AsciiDoc{}, templates))
+	site.Update(generateOutputFiles(files, cfg, errs, report.New()))
+	errs.report()
+}
+
+func init() {
+	addSourceFlags(serveCmd)
+	serveCmd.Flags().StringSliceP("template", "t", []string{}, "Go template for the page layout, either for all languages or per language (e.g. sh=templates/script.tmpl)")
+	serveCmd.Flags().String("host", "localhost", "Host to serve the preview on (use 0.0.0.0 inside a Docker container)")
+	serveCmd.Flags().IntP("port", "p", 8080, "Port to serve the preview on")
+
+	RegisterSubCommand(serveCmd)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sommerfeld-io/source2adoc/internal/codefiles"
+	"github.com/sommerfeld-io/source2adoc/internal/config"
+	"github.com/sommerfeld-io/source2adoc/internal/preview"
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ServeCmd(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := serveCmd
+
+	assert.Equal("serve", cmd.Use, "Incorrect command name")
+
+	flags := cmd.Flags()
+	assert.NotNil(flags.Lookup("source-dir"), "Missing --source-dir flag")
+	assert.NotNil(flags.Lookup("config"), "Missing --config flag")
+	assert.NotNil(flags.Lookup("template"), "Missing --template flag")
+	assert.NotNil(flags.Lookup("host"), "Missing --host flag")
+	assert.NotNil(flags.Lookup("port"), "Missing --port flag")
+}
+
+func Test_ShouldLoadServeConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	cmd := &cobra.Command{}
+	addSourceFlags(cmd)
+	cmd.Flags().String("format", "", "")
+	err := cmd.Flags().Parse([]string{"--source-dir", "src", "--format", "markdown"})
+	assert.Nil(err, "Error parsing flags")
+
+	cfg, err := loadServeConfig(cmd)
+	assert.Nil(err, "Error loading config")
+	assert.Equal([]string{"src"}, cfg.SourceDirs, "Source dir should be taken from the flag")
+	assert.Equal(render.FormatAsciiDoc, cfg.Format, "Preview should always use AsciiDoc")
+	assert.Equal(".", cfg.OutputDir, "Pages should be generated relative to the root of the preview")
+}
+
+func Test_ShouldLinkPagesInPreviewWithAbsoluteSourceDir(t *testing.T) {
+	assert := assert.New(t)
+
+	srcDir := filepath.Join(t.TempDir(), "src")
+	err := os.MkdirAll(srcDir, 0755)
+	assert.Nil(err, "Error creating source dir")
+	err = os.WriteFile(filepath.Join(srcDir, "run.sh"), []byte("## Run the app\n## @see lib.sh\n"), 0644)
+	assert.Nil(err, "Error creating code file")
+	err = os.WriteFile(filepath.Join(srcDir, "lib.sh"), []byte("## Library\n"), 0644)
+	assert.Nil(err, "Error creating code file")
+
+	cfg := config.New()
+	cfg.SourceDirs = []string{srcDir}
+	cfg.OutputDir = "."
+	templates, err := codefiles.NewPageTemplates([]string{})
+	assert.Nil(err, "Error reading templates")
+	site := preview.NewSite()
+	generatePreview(site, cfg, templates)
+
+	recorder := httptest.NewRecorder()
+	site.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, filepath.ToSlash(filepath.Join(srcDir, "run-sh.adoc")), nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	assert.Nil(err, "Error reading response")
+	assert.Equal(http.StatusOK, recorder.Code, "Page should be served")
+	libPage := filepath.ToSlash(filepath.Join(srcDir, "lib-sh.adoc"))
+	assert.Contains(string(body), `<a href="`+libPage+`">lib.sh</a>`, "Xref should link the page of the absolute source dir")
+
+	recorder = httptest.NewRecorder()
+	site.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, libPage, nil))
+	assert.Equal(http.StatusOK, recorder.Code, "Linked page should be served")
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import "runtime/debug"
+
+// Version is the version of the app. It is set at build time, e.g.
+// `go build -ldflags "-X github.com/sommerfeld-io/source2adoc/cmd.Version=v1.0.0"`.
+var Version = "dev"
+
+// toolVersion returns the Version of the app. Development builds are identified by the VCS
+// revision they were built from (if available), so changes to the app are detected as well.
+func toolVersion() string {
+	if Version != "dev" {
+		return Version
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Version
+	}
+	version := Version
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			version += "-" + setting.Value
+		}
+		if setting.Key == "vcs.modified" && setting.Value == "true" {
+			version += "-dirty"
+		}
+	}
+	return version
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sommerfeld-io/source2adoc/internal/codefiles"
+	"github.com/sommerfeld-io/source2adoc/internal/config"
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+	"github.com/sommerfeld-io/source2adoc/internal/pipeline"
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+	"github.com/sommerfeld-io/source2adoc/internal/report"
+	"github.com/sommerfeld-io/source2adoc/internal/watch"
+)
+
+// watchDelay is the time without further changes before the documentation is updated.
+const watchDelay = 200 * time.Millisecond
+
+// watchDocumentation writes the documentation once and keeps the app running afterwards. Whenever
+// code files in the source dirs change, only the documentation files of the affected code files
+// are updated. Changes inside the output dir are ignored, so writing the documentation does not
+// trigger another update. The documentation files of deleted (or renamed) code files are deleted. The JSON
+// export does not support watching, because it covers all code files in a single file.
+func watchDocumentation(cfg *config.Config, renderer render.Renderer, templates *codefiles.PageTemplates) {
+	if cfg.Format == formatJSON {
+		handleError(fmt.Errorf("--watch does not support the %s format", formatJSON))
+	}
+
+	watcher, err := watch.New(cfg.SourceDirs, []string{cfg.OutputDir}, watchDelay)
+	handleError(err)
+	defer watcher.Close()
+
+	errs := newWatchErrorCollector()
+	files := streamCodeFiles(cfg, errs, codeFilePreparer(renderer, templates))
+	writeDocumentation(files, cfg, templates, true, errs, report.New())
+	errs.report()
+
+	fmt.Println("watching " + strings.Join(cfg.SourceDirs, ", ") + " for changes (press Ctrl+C to stop)")
+	err = watcher.Run(nil, func(changed []string) {
+		updateDocumentation(changed, cfg, renderer, templates)
+	})
+	handleError(err)
+}
+
+// newWatchErrorCollector returns an errorCollector which reports the errors without ending the
+// app, so the app keeps watching for changes which fix the errors.
+func newWatchErrorCollector() *errorCollector {
+	errs := newErrorCollector(false)
+	errs.exit = func(code int) {}
+	return errs
+}
+
+// updateDocumentation updates the documentation files of all code files affected by the changed
+// paths. The code files are searched again, so new code files and changed ignore files are taken
+// into account. Documentation files whose code file was affected but is no code file anymore
+// (because it was deleted, renamed or excluded) are deleted. The manifest is only written if it
+// changed.
+func updateDocumentation(changed []string, cfg *config.Config, renderer render.Renderer, templates *codefiles.PageTemplates) {
+	errs := newWatchErrorCollector()
+	files := findCodeFiles(cfg, errs)
+	prepareCodeFiles(files, renderer, templates)
+
+	manifest, err := output.LoadManifest(cfg.OutputDir)
+	handleError(err)
+	manifest.UseSettings(Version, settingsHash(cfg, templates))
+
+	writeCodeFiles(pipeline.Items(affectedCodeFiles(files, changed)), cfg, manifest, true, errs, report.New())
+
+	sources := map[string]bool{}
+	for _, file := range files {
+		sources[absolutePath(file.SourcePath())] = true
+	}
+	changedPaths := []string{}
+	for _, path := range changed {
+		changedPaths = append(changedPaths, absolutePath(path))
+	}
+	err = manifest.PruneSources(func(source string) bool {
+		source = absolutePath(source)
+		return watch.IsAffected(source, changedPaths) && !sources[source]
+	})
+	handleError(err)
+	if manifest.Changed() {
+		err = manifest.Write()
+		handleError(err)
+	}
+	errs.report()
+}
+
+// absolutePath returns the absolute path, so the sources from the manifest (which are based on
+// the output directory) can be compared with the paths of the code files (which are based on the
+// source directories). Paths which cannot be resolved are returned as is.
+func absolutePath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return abs
+}
+
+// affectedCodeFiles returns the code files which are affected by the changed paths.
+func affectedCodeFiles(files []*codefiles.CodeFile, changed []string) []*codefiles.CodeFile {
+	affected := []*codefiles.CodeFile{}
+	for _, file := range files {
+		if watch.IsAffected(file.SourcePath(), changed) {
+			affected = append(affected, file)
+		}
+	}
+	return affected
+}
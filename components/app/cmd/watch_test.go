@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/sommerfeld-io/source2adoc/internal/codefiles"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldFindAffectedCodeFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	files := []*codefiles.CodeFile{
+		codefiles.NewCodeFile("run.sh"),
+		codefiles.NewCodeFile("src/lib.sh"),
+		codefiles.NewCodeFile("src/bin/deploy.sh"),
+	}
+
+	tests := []struct {
+		changed  []string
+		expected []*codefiles.CodeFile
+	}{
+		{changed: []string{"run.sh"}, expected: []*codefiles.CodeFile{files[0]}},
+		{changed: []string{"src/bin"}, expected: []*codefiles.CodeFile{files[2]}},
+		{changed: []string{"src"}, expected: []*codefiles.CodeFile{files[1], files[2]}},
+		{changed: []string{"docs/run-sh.adoc"}, expected: []*codefiles.CodeFile{}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, affectedCodeFiles(files, test.changed), "Incorrect affected code files")
+	}
+}
@@ -0,0 +1,144 @@
+package antora
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Module represents an Antora module in the file system. The module directory is expected to
+// contain a `pages` folder with the documentation pages.
+type Module struct {
+	dir string
+}
+
+// NewModule acts as a constructor for a new Module instance.
+func NewModule(dir string) *Module {
+	return &Module{
+		dir: dir,
+	}
+}
+
+// Dir returns the root directory of the Module.
+func (m *Module) Dir() string {
+	return m.dir
+}
+
+// PagesDir returns the directory containing the documentation pages of the Module.
+func (m *Module) PagesDir() string {
+	return filepath.Join(m.dir, "pages")
+}
+
+// NavFile returns the path to the nav.adoc file of the Module.
+func (m *Module) NavFile() string {
+	return filepath.Join(m.dir, "nav.adoc")
+}
+
+// navNode represents a directory inside the pages folder. Each node holds the pages of the
+// directory and its subdirectories.
+type navNode struct {
+	pages    []string
+	children map[string]*navNode
+}
+
+func newNavNode() *navNode {
+	return &navNode{
+		pages:    []string{},
+		children: map[string]*navNode{},
+	}
+}
+
+// add inserts a page (path relative to the pages folder) into the tree.
+func (node *navNode) add(page string) {
+	current := node
+	segments := strings.Split(filepath.ToSlash(filepath.Dir(page)), "/")
+	for _, segment := range segments {
+		if segment == "." {
+			continue
+		}
+		if _, ok := current.children[segment]; !ok {
+			current.children[segment] = newNavNode()
+		}
+		current = current.children[segment]
+	}
+	current.pages = append(current.pages, filepath.ToSlash(page))
+}
+
+// render writes the node as AsciiDoc list. Pages are listed before subdirectories. The nesting
+// level of the list mirrors the directory tree.
+func (node *navNode) render(level int) string {
+	bullet := strings.Repeat("*", level)
+	nav := ""
+
+	sort.Slice(node.pages, func(i, j int) bool {
+		return pageSortKey(node.pages[i]) < pageSortKey(node.pages[j])
+	})
+	for _, page := range node.pages {
+		nav += bullet + " xref:" + page + "[]\n"
+	}
+
+	dirs := make([]string, 0, len(node.children))
+	for dir := range node.children {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		nav += bullet + " " + dir + "\n"
+		nav += node.children[dir].render(level + 1)
+	}
+	return nav
+}
+
+// pageSortKey makes sure an index.adoc is always the first entry of its directory.
+func pageSortKey(page string) string {
+	if filepath.Base(page) == "index.adoc" {
+		return ""
+	}
+	return page
+}
+
+// GenerateNav walks the pages folder of the Module and returns the contents of a nav.adoc file.
+// The nesting of the navigation mirrors the directory tree of the pages folder.
+func (m *Module) GenerateNav() (string, error) {
+	root := newNavNode()
+	pagesDir := m.PagesDir()
+
+	err := filepath.WalkDir(pagesDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to walk the filesystem: %v", err)
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".adoc" {
+			return nil
+		}
+
+		page, err := filepath.Rel(pagesDir, path)
+		if err != nil {
+			return fmt.Errorf("failed to resolve page path: %v", err)
+		}
+		root.add(page)
+		return nil
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to list pages: %w", err)
+	}
+	return root.render(1), nil
+}
+
+// WriteNav generates the navigation of the Module and writes it to the nav.adoc file in the
+// module root. An existing nav.adoc file is overwritten.
+func (m *Module) WriteNav() error {
+	nav, err := m.GenerateNav()
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(m.NavFile(), []byte(nav), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write nav file: %v", err)
+	}
+	return nil
+}
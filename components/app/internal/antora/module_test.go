@@ -0,0 +1,81 @@
+package antora
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createPages(t *testing.T, moduleDir string, pages []string) {
+	for _, page := range pages {
+		path := filepath.Join(moduleDir, "pages", page)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		assert.Nil(t, err, "Error creating pages directory")
+
+		err = os.WriteFile(path, []byte("= "+filepath.Base(page)+"\n"), 0644)
+		assert.Nil(t, err, "Error creating page")
+	}
+}
+
+func Test_ShouldGetPathsFromModule(t *testing.T) {
+	assert := assert.New(t)
+
+	module := NewModule("docs/modules/source2adoc")
+
+	assert.Equal("docs/modules/source2adoc", module.Dir(), "Incorrect module dir")
+	assert.Equal("docs/modules/source2adoc/pages", module.PagesDir(), "Incorrect pages dir")
+	assert.Equal("docs/modules/source2adoc/nav.adoc", module.NavFile(), "Incorrect nav file")
+}
+
+func Test_ShouldGenerateNav(t *testing.T) {
+	assert := assert.New(t)
+
+	moduleDir := t.TempDir()
+	createPages(t, moduleDir, []string{
+		"src/main/dockerfile.adoc",
+		"src/main/scripts/build-sh.adoc",
+		"src/makefile.adoc",
+		"index.adoc",
+		"docker-compose-yml.adoc",
+		"src/main/ignore-me.txt",
+	})
+
+	expectedNav := `* xref:index.adoc[]
+* xref:docker-compose-yml.adoc[]
+* src
+** xref:src/makefile.adoc[]
+** main
+*** xref:src/main/dockerfile.adoc[]
+*** scripts
+**** xref:src/main/scripts/build-sh.adoc[]
+`
+
+	module := NewModule(moduleDir)
+	nav, err := module.GenerateNav()
+	assert.Nil(err, "Error generating nav")
+	assert.Equal(expectedNav, nav, "Incorrect nav")
+}
+
+func Test_ShouldWriteNav(t *testing.T) {
+	assert := assert.New(t)
+
+	moduleDir := t.TempDir()
+	createPages(t, moduleDir, []string{"src/script-sh.adoc"})
+
+	module := NewModule(moduleDir)
+	err := module.WriteNav()
+	assert.Nil(err, "Error writing nav file")
+
+	content, err := os.ReadFile(module.NavFile())
+	assert.Nil(err, "Error reading nav file")
+	assert.Equal("* src\n** xref:src/script-sh.adoc[]\n", string(content), "Incorrect nav file content")
+}
+
+func Test_ShouldFailToGenerateNavWithoutPagesDir(t *testing.T) {
+	module := NewModule(filepath.Join(t.TempDir(), "missing"))
+
+	_, err := module.GenerateNav()
+	assert.NotNil(t, err, "Error should not be nil")
+}
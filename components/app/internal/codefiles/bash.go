@@ -0,0 +1,87 @@
+package codefiles
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+)
+
+// bashFunctionPattern matches Bash function definitions like `function foo {`, `function foo() {`
+// and `foo() {`. The opening brace is optional to allow the brace on the next line. One-line
+// functions like `foo() { echo foo; }` are matched as well.
+var bashFunctionPattern = regexp.MustCompile(`^\s*(?:function\s+([\w:.-]+)\s*(?:\(\s*\))?|([\w:.-]+)\s*\(\s*\))\s*(?:\{.*)?$`)
+
+// bashFunction represents a single function definition of a Bash script together with the
+// relevant comments (marked with `##`) immediately preceding it.
+type bashFunction struct {
+	name string
+	docs []string
+}
+
+// bashFunctions finds all function definitions of a Bash script, documented or not. The header
+// documentation is never used as documentation of a function, so a function directly following
+// the header documentation is undocumented.
+func (cf *CodeFile) bashFunctions() []bashFunction {
+	functions := []bashFunction{}
+	functionDocs := []string{}
+	_, headerEnd := cf.headerDocBlock()
+	lines := strings.Split(cf.fileContent, "\n")
+	for _, line := range lines[headerEnd:] {
+		if text, ok := cf.docLine(line); ok {
+			functionDocs = append(functionDocs, text)
+			continue
+		}
+
+		name := bashFunctionName(line)
+		if name != "" {
+			functions = append(functions, bashFunction{name: name, docs: functionDocs})
+		}
+		functionDocs = []string{}
+	}
+	return functions
+}
+
+// parseFunctionDocs finds all relevant comments (marked with `##`) immediately preceding a Bash
+// function definition and returns each of them as a separate section. The function name is used
+// as the heading of the section.
+//
+// See "Rules for the function documentation" in `docs/modules/ROOT/pages/index.adoc`.
+func (cf *CodeFile) parseFunctionDocs() []DocumentationPart {
+	parts := []DocumentationPart{}
+	for _, function := range cf.bashFunctions() {
+		if len(function.docs) == 0 {
+			continue
+		}
+		docs := cf.parseTags(function.docs)
+		part := NewDocumentationPart(DocumentationPartFunction, renderFunctionDocs(cf.docsRenderer(), function.name, docs))
+		part.name = function.name
+		part.tags = docs
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// renderFunctionDocs renders the documentation of a function as a section with the function name
+// as heading. Metadata tags (e.g. `@since`) are rendered as a table at the top of the section.
+func renderFunctionDocs(renderer render.Renderer, name string, docs *taggedDocs) string {
+	content := "\n" + renderer.Heading(1, name) + "\n"
+	if len(docs.metadata) > 0 {
+		content += renderer.Table(render.Table{Cols: []int{1, 5}, Rows: docs.metadata})
+		content += "\n"
+	}
+	return content + docs.render(renderer)
+}
+
+// bashFunctionName returns the name of the function defined in the given line. If the line is
+// no function definition, an empty string is returned.
+func bashFunctionName(line string) string {
+	matches := bashFunctionPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return ""
+	}
+	if matches[1] != "" {
+		return matches[1]
+	}
+	return matches[2]
+}
@@ -1,21 +1,17 @@
 package codefiles
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
-)
+	"text/template"
 
-// SupportedCodeFilenames maps supported file extensions to their corresponding languages.
-var SupportedCodeFilenames = map[string]string{
-	".yml":        LanguageYml,
-	".yaml":       LanguageYml,
-	"Dockerfile":  LanguageDockerfile,
-	"Vagrantfile": LanguageVagrant,
-	"Makefile":    LanguageMake,
-	".sh":         LanguageBash,
-}
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+)
 
 // CodeFile represents a source code file in the file system.
 type CodeFile struct {
@@ -25,6 +21,8 @@ type CodeFile struct {
 	supportedLang      bool
 	fileContent        string
 	documentationParts []DocumentationPart
+	renderer           render.Renderer
+	template           *template.Template
 }
 
 // New acts as a constructor for a new CodeFile instance.
@@ -40,6 +38,23 @@ func NewCodeFile(fullPath string) *CodeFile {
 	}
 }
 
+// NewCodeFileWithLanguage acts as a constructor for a new CodeFile instance with an explicitly
+// assigned language instead of identifying the language from the filename.
+func NewCodeFileWithLanguage(fullPath string, lang string) *CodeFile {
+	path, name := splitPathAndFilename(fullPath)
+	_, supported := SupportedLanguages.ByName(lang)
+	if !supported {
+		lang = LanguageNotSupported
+	}
+
+	return &CodeFile{
+		path:          path,
+		name:          name,
+		lang:          lang,
+		supportedLang: supported,
+	}
+}
+
 // Split the path and filename
 // If no "/" is found, return the entire path as the filename
 func splitPathAndFilename(path string) (string, string) {
@@ -50,12 +65,11 @@ func splitPathAndFilename(path string) (string, string) {
 // Identify the language of the file based on the filename or extension
 // Return the language and a boolean indicating if the language is supported
 func identifyLanguage(filename string) (string, bool) {
-	for key, value := range SupportedCodeFilenames {
-		if strings.HasSuffix(filename, key) || strings.HasPrefix(filename, value) {
-			return value, true
-		}
+	lang, ok := SupportedLanguages.ByFilename(filename)
+	if !ok {
+		return LanguageNotSupported, false
 	}
-	return LanguageNotSupported, false
+	return lang.Name(), true
 }
 
 // Path returns the path of the CodeFile.
@@ -68,6 +82,12 @@ func (cf *CodeFile) Filename() string {
 	return cf.name
 }
 
+// SourcePath returns the path of the CodeFile including the filename. The path is cleaned, so code
+// files found in the working directory have no leading slash (e.g. `run.sh` instead of `/run.sh`).
+func (cf *CodeFile) SourcePath() string {
+	return filepath.Join(cf.path, cf.name)
+}
+
 // Language returns the language of the CodeFile.
 func (cf *CodeFile) Language() string {
 	return cf.lang
@@ -78,19 +98,60 @@ func (cf *CodeFile) IsSupportedLanguage() bool {
 	return cf.supportedLang
 }
 
+// SetRenderer sets the Renderer for the output format of the documentation. The renderer must be
+// set before the CodeFile is parsed. Without renderer, the documentation is rendered as AsciiDoc.
+func (cf *CodeFile) SetRenderer(renderer render.Renderer) {
+	cf.renderer = renderer
+}
+
+// SetPageTemplate sets the template for the layout of the documentation page (see PageData).
+// Without template, the DefaultTemplate is used.
+func (cf *CodeFile) SetPageTemplate(tmpl *template.Template) {
+	cf.template = tmpl
+}
+
+// docsRenderer returns the Renderer for the documentation of the CodeFile.
+func (cf *CodeFile) docsRenderer() render.Renderer {
+	if cf.renderer == nil {
+		return &render.AsciiDoc{}
+	}
+	return cf.renderer
+}
+
 // FileContent returns the content of the CodeFile.
 func (cf *CodeFile) FileContent() string {
 	return cf.fileContent
 }
 
-// ReadFileContent reads the content of the CodeFile from the file system.
-func (cf *CodeFile) ReadFileContent() error {
-	fullPath := cf.path + "/" + cf.name
-	if cf.path == "" {
-		fullPath = cf.name
+// ContentHash returns the SHA-256 hash of the content of the CodeFile. The content must be read
+// before.
+func (cf *CodeFile) ContentHash() string {
+	hash := sha256.Sum256([]byte(cf.fileContent))
+	return hex.EncodeToString(hash[:])
+}
+
+// DocumentationHash returns the SHA-256 hash of the content of the CodeFile together with the
+// resolved targets of its `@see` tags. Besides the settings (e.g. the output format), these are
+// the only inputs of the documentation file, so the hash changes whenever the documentation of
+// the CodeFile changes, including a referenced code file being added or removed. The CodeFile must
+// be parsed before.
+func (cf *CodeFile) DocumentationHash() string {
+	hash := sha256.New()
+	hash.Write([]byte(cf.fileContent))
+	for _, part := range cf.documentationParts {
+		if part.tags == nil {
+			continue
+		}
+		for _, see := range part.tags.see {
+			fmt.Fprintf(hash, "\n%s", see.xref)
+		}
 	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
 
-	content, err := os.ReadFile(fullPath)
+// ReadFileContent reads the content of the CodeFile from the file system.
+func (cf *CodeFile) ReadFileContent() error {
+	content, err := os.ReadFile(cf.SourcePath())
 	if err != nil {
 		return fmt.Errorf("failed to read code file: %v", err)
 	}
@@ -100,14 +161,68 @@ func (cf *CodeFile) ReadFileContent() error {
 
 // Parse parses the CodeFile and extracts the documentation parts.
 func (cf *CodeFile) Parse() error {
-	cf.parseMetadata()
-	err := cf.parseHeaderDocs()
+	header := cf.parseTags(cf.headerDocLines())
+	cf.parseMetadata(header.metadata)
+	err := cf.parseHeaderDocs(header)
 	if err != nil {
 		return fmt.Errorf("failed to parse header docs from code file: %v", err)
 	}
+
+	lang, ok := SupportedLanguages.ByName(cf.lang)
+	if !ok {
+		return nil
+	}
+	for _, parser := range lang.Parsers() {
+		cf.documentationParts = append(cf.documentationParts, parser(cf)...)
+	}
 	return nil
 }
 
+// DocumentationParts returns all DocumentationParts of the CodeFile. The CodeFile must be parsed
+// before.
+func (cf *CodeFile) DocumentationParts() []DocumentationPart {
+	return cf.documentationParts
+}
+
+// Warnings returns hints about missing documentation in the parsed CodeFile. Warnings do not
+// prevent the documentation file from being created.
+func (cf *CodeFile) Warnings() []string {
+	warnings := []string{}
+	if !cf.hasHeaderDocs() {
+		warnings = append(warnings, WarningNoHeaderDocs)
+	}
+	return warnings
+}
+
+// hasHeaderDocs returns true if the CodeFile contains header documentation.
+func (cf *CodeFile) hasHeaderDocs() bool {
+	for _, part := range cf.documentationParts {
+		if part.sectionType == DocumentationPartHeader && part.tags != nil && !part.tags.isEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// commentMarker returns the marker of relevant comments for the language of the CodeFile.
+func (cf *CodeFile) commentMarker() string {
+	lang, ok := SupportedLanguages.ByName(cf.lang)
+	if !ok {
+		return DefaultCommentMarker
+	}
+	return lang.CommentMarker()
+}
+
+// docLine returns the text of a relevant comment line without the comment marker. If the line
+// does not start with the comment marker, ok is false.
+func (cf *CodeFile) docLine(line string) (text string, ok bool) {
+	marker := cf.commentMarker()
+	if !strings.HasPrefix(line, marker) {
+		return "", false
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, marker)), true
+}
+
 // parsedDocumentation returns the parsed documentation of the CodeFile. The parsed documentation
 // is ready to be written to a file.
 func (cf *CodeFile) parsedDocumentation() string {
@@ -118,82 +233,104 @@ func (cf *CodeFile) parsedDocumentation() string {
 	return parsedDocs
 }
 
-func (cf *CodeFile) parseMetadata() {
-	asciidoc := "= " + cf.name + "\n"
-	asciidoc += "\n"
-	asciidoc += "[cols=\"1,5\"]\n"
-	asciidoc += "|===\n"
-	asciidoc += "|Language |" + cf.Language() + "\n"
-	if cf.path == "" {
-		asciidoc += "|Path |" + cf.Filename() + "\n"
-	} else {
-		asciidoc += "|Path |" + cf.Path() + "/" + cf.Filename() + "\n"
+// parseMetadata renders the title and the metadata table of the documentation page as separate
+// parts. The metadata rows from JavaDoc-style tags (e.g. `@author`) are appended to the table.
+func (cf *CodeFile) parseMetadata(metadata [][]string) {
+	rows := [][]string{
+		{"Language", cf.Language()},
+		{"Path", cf.SourcePath()},
 	}
-	asciidoc += "|===\n"
-	asciidoc += "\n"
 
-	part := DocumentationPart{
-		sectionType:    DocumentationPartMetadata,
-		sectionContent: asciidoc,
-	}
-	cf.documentationParts = append(cf.documentationParts, part)
+	renderer := cf.docsRenderer()
+	content := "\n"
+	content += renderer.Table(render.Table{Cols: []int{1, 5}, Rows: append(rows, metadata...)})
+	content += "\n"
+
+	cf.documentationParts = append(cf.documentationParts,
+		NewDocumentationPart(DocumentationPartTitle, renderer.Heading(0, cf.name)),
+		NewDocumentationPart(DocumentationPartMetadata, content),
+	)
 }
 
-// parseHeaderDocs finds all relevant comments (marked with `##`) at the beginning of the file
-// and stores them in the CodeFile.
+// headerDocLines finds all relevant comments (marked with `##` or the comment marker of the
+// language) at the beginning of the file.
 //
 // See "Rules for the header documentation" in `docs/modules/ROOT/pages/index.adoc`.
-func (cf *CodeFile) parseHeaderDocs() error {
-	headerDocs := ""
+func (cf *CodeFile) headerDocLines() []string {
+	headerDocs, _ := cf.headerDocBlock()
+	return headerDocs
+}
+
+// headerDocBlock returns the header documentation together with the index of the first line
+// after the header documentation. The header documentation ends at the first empty line or at the
+// first line after the header documentation which is no comment (e.g. a function definition).
+func (cf *CodeFile) headerDocBlock() ([]string, int) {
+	headerDocs := []string{}
 	lines := strings.Split(cf.fileContent, "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "##") {
-			trimmedLine := strings.TrimPrefix(line, "##")
-			trimmedLine = strings.TrimSpace(trimmedLine)
-			headerDocs += trimmedLine + "\n"
-		} else if line == "" {
-			break
+	for i, line := range lines {
+		if text, ok := cf.docLine(line); ok {
+			headerDocs = append(headerDocs, text)
+		} else if line == "" || (len(headerDocs) > 0 && !isComment(line)) {
+			return headerDocs, i
 		}
 	}
+	return headerDocs, len(lines)
+}
+
+// isComment returns true if the line is a comment. All supported languages use `#` for comments.
+func isComment(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "#")
+}
 
+// parseHeaderDocs renders the header documentation and stores it in the CodeFile.
+func (cf *CodeFile) parseHeaderDocs(header *taggedDocs) error {
 	part := DocumentationPart{
 		sectionType:    DocumentationPartHeader,
-		sectionContent: headerDocs,
+		sectionContent: header.render(cf.docsRenderer()),
+		tags:           header,
 	}
 	cf.documentationParts = append(cf.documentationParts, part)
 
 	return nil
 }
 
+// documentationPage returns the path of the documentation file relative to the output directory.
+func (cf *CodeFile) documentationPage() string {
+	if cf.path == "" {
+		return cf.documentationFileName()
+	}
+	return cf.Path() + "/" + cf.documentationFileName()
+}
+
 // documentationFileName returns the name of the documentation file for the CodeFile in kebab-case.
 func (cf *CodeFile) documentationFileName() string {
 	name := strings.ReplaceAll(cf.Filename(), ".", "-")
 	name = strings.ToLower(name)
-	return name + ".adoc"
+	return name + cf.docsRenderer().FileExtension()
 }
 
-// WriteDocumentationFile writes the parsed documentation of the CodeFile to a file.
-func (cf *CodeFile) WriteDocumentationFile(outputDir string) error {
-	parsedDocs := cf.parsedDocumentation()
-	codeFile := cf.Path() + "/" + cf.Filename()
-	adocFile := outputDir + "/" + cf.Path() + "/" + cf.documentationFileName()
-
-	err := os.MkdirAll(filepath.Dir(adocFile), 0755)
+// DocumentationFile returns the documentation file of the CodeFile inside the output directory
+// without writing it. The layout of the file is defined by the page template of the CodeFile.
+func (cf *CodeFile) DocumentationFile(outputDir string) (*output.File, error) {
+	parsedDocs, err := cf.renderPage()
 	if err != nil {
-		return fmt.Errorf("failed to create directory: %v", err)
+		return nil, err
 	}
+	return output.NewFile(cf.SourcePath(), cf.DocumentationPath(outputDir), parsedDocs), nil
+}
 
-	file, err := os.OpenFile(adocFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write file: %v", err)
-	}
-	defer file.Close()
+// DocumentationPath returns the path of the documentation file of the CodeFile inside the output
+// directory. The CodeFile does not need to be parsed.
+func (cf *CodeFile) DocumentationPath(outputDir string) string {
+	return filepath.Clean(outputDir + "/" + cf.Path() + "/" + cf.documentationFileName())
+}
 
-	_, err = file.WriteString(parsedDocs)
+// WriteDocumentationFile writes the parsed documentation of the CodeFile to a file. The layout of
+// the file is defined by the page template of the CodeFile.
+func (cf *CodeFile) WriteDocumentationFile(outputDir string) error {
+	file, err := cf.DocumentationFile(outputDir)
 	if err != nil {
-		return fmt.Errorf("failed to write content to file: %v", err)
+		return err
 	}
-
-	fmt.Println(codeFile + "    ==>    " + adocFile)
-	return nil
+	return file.Write()
 }
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+	"github.com/sommerfeld-io/source2adoc/internal/render"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,6 +47,9 @@ func Test_ShouldIdentifyLanguage(t *testing.T) {
 		{filename: "Makefile", expected: LanguageMake, supported: true},
 		{filename: "script.sh", expected: LanguageBash, supported: true},
 		{filename: "script.go", expected: LanguageNotSupported, supported: false},
+		{filename: "sh-completion", expected: LanguageNotSupported, supported: false},
+		{filename: "yml-notes.txt", expected: LanguageNotSupported, supported: false},
+		{filename: "app.Dockerfile", expected: LanguageDockerfile, supported: true},
 	}
 
 	for _, test := range tests {
@@ -71,6 +76,10 @@ func Test_ShouldGetDataFromGetterFunctions(t *testing.T) {
 	actualName := codeFile.Filename()
 	assert.Equal(expectedName, actualName, "Incorrect filename")
 
+	expectedSourcePath := "/path/to/source.sh"
+	actualSourcePath := codeFile.SourcePath()
+	assert.Equal(expectedSourcePath, actualSourcePath, "Incorrect source path")
+
 	expectedLang := LanguageBash
 	actualLang := codeFile.Language()
 	assert.Equal(expectedLang, actualLang, "Incorrect path language")
@@ -139,6 +148,61 @@ ut labore et dolore magna aliquyam erat, sed diam voluptua.
 	docs := codeFile.parsedDocumentation()
 	assert.Equal(expectedDocs, docs, "Incorrect parsed documentation")
 }
+
+func Test_ShouldParseDocumentationAsMarkdown(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		path:          "src",
+		name:          "script.sh",
+		lang:          LanguageBash,
+		supportedLang: true,
+		renderer:      &render.Markdown{},
+		fileContent: `#!/bin/bash
+## Lorem ipsum dolor sit amet.
+## @author Sebastian Sommerfeld
+## @see lib/util.sh
+
+## Greet someone.
+## @arg $1 string The name
+function greet() {
+  echo "Hello $1"
+}
+`,
+		documentationParts: []DocumentationPart{},
+	}
+
+	expectedDocs := `# script.sh
+
+|  |  |
+| --- | --- |
+| Language | ` + LanguageBash + ` |
+| Path | src/script.sh |
+| Author | Sebastian Sommerfeld |
+
+Lorem ipsum dolor sit amet.
+
+**See also**
+
+* [lib/util.sh](lib/util-sh.md)
+
+## greet
+
+Greet someone.
+
+**Arguments**
+
+| Name | Type | Description |
+| --- | --- | --- |
+| $1 | string | The name |
+`
+
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+	assert.Equal(expectedDocs, codeFile.parsedDocumentation(), "Incorrect parsed documentation")
+	assert.Equal("script-sh.md", codeFile.documentationFileName(), "Incorrect documentation file name")
+}
+
 func Test_ShouldTranslateDocumentationFileName(t *testing.T) {
 	codeFile := &CodeFile{
 		path: filepath.Join(TestSourceDir, "good"),
@@ -176,3 +240,253 @@ func Test_ShouldWriteDocumentationFile(t *testing.T) {
 
 	os.Remove(expectedAdocFile)
 }
+
+func Test_ShouldCreateDocumentationFile(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := NewCodeFile("some/path/unittest.sh")
+	codeFile.fileContent = "## Lorem ipsum\n"
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	file, err := codeFile.DocumentationFile("docs")
+	assert.Nil(err, "Error creating documentation file")
+	assert.Equal("some/path/unittest.sh", file.Source, "Incorrect source")
+	assert.Equal("docs/some/path/unittest-sh.adoc", file.Path, "Incorrect path")
+	assert.Equal("// "+output.GeneratedHeader+"\n"+codeFile.parsedDocumentation(), file.Content, "Incorrect content")
+
+	codeFile = NewCodeFile("run.sh")
+	file, err = codeFile.DocumentationFile("docs")
+	assert.Nil(err, "Error creating documentation file")
+	assert.Equal("run.sh", file.Source, "Code files in the working directory should have no leading slash")
+	assert.Equal("docs/run-sh.adoc", file.Path, "Incorrect path")
+}
+
+func Test_ShouldReportWarnings(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		content  string
+		expected []string
+	}{
+		{content: "## Lorem ipsum\n", expected: []string{}},
+		{content: "## @arg $1 string Name\n", expected: []string{}},
+		{content: "#!/bin/bash\n\n## Greet someone.\ngreet() {\n}\n", expected: []string{WarningNoHeaderDocs}},
+		{content: "", expected: []string{WarningNoHeaderDocs}},
+	}
+
+	for _, test := range tests {
+		codeFile := NewCodeFile("some/path/unittest.sh")
+		codeFile.fileContent = test.content
+		err := codeFile.Parse()
+		assert.Nil(err, "Error parsing documentation")
+		assert.Equal(test.expected, codeFile.Warnings(), "Incorrect warnings for "+test.content)
+		assert.NotEmpty(codeFile.DocumentationParts(), "Parts should be returned")
+	}
+}
+
+func Test_ShouldHashFileContent(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := NewCodeFile("some/path/unittest.sh")
+	codeFile.fileContent = "## Lorem ipsum\n"
+	other := NewCodeFile("some/path/other.sh")
+	other.fileContent = "## Dolor sit amet\n"
+
+	assert.Len(codeFile.ContentHash(), 64, "Hash should be a hex encoded SHA-256 hash")
+	assert.Equal(codeFile.ContentHash(), codeFile.ContentHash(), "Hash should be stable")
+	assert.NotEqual(codeFile.ContentHash(), other.ContentHash(), "Different content should result in different hashes")
+	assert.Equal("docs/some/path/unittest-sh.adoc", codeFile.DocumentationPath("docs"), "Incorrect documentation path")
+}
+
+func Test_ShouldIdentifyBashFunctionName(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{line: "function foo {", expected: "foo"},
+		{line: "function foo() {", expected: "foo"},
+		{line: "function foo", expected: "foo"},
+		{line: "foo() {", expected: "foo"},
+		{line: "  util::log_info () {", expected: "util::log_info"},
+		{line: "foo()", expected: "foo"},
+		{line: "echo foo", expected: ""},
+		{line: "foo() { echo inline; }", expected: "foo"},
+		{line: "function foo { echo inline; }", expected: "foo"},
+		{line: "foo=bar", expected: ""},
+		{line: "echo foo() {", expected: ""},
+		{line: "## function foo {", expected: ""},
+	}
+
+	for _, test := range tests {
+		name := bashFunctionName(test.line)
+		assert.Equal(test.expected, name, "Incorrect function name for: "+test.line)
+	}
+}
+
+func Test_ShouldParseFunctionDocs(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		path:          "some/path",
+		name:          "functions.sh",
+		lang:          LanguageBash,
+		supportedLang: true,
+		fileContent: `#!/bin/bash
+## Header docs
+
+## Say hello.
+## @arg $1 string The name
+function hello {
+  echo "Hello $1"
+}
+
+# Regular comment, not part of the docs
+function undocumented {
+  echo "undocumented"
+}
+
+## Not directly above a function
+
+goodbye() {
+  echo "Goodbye"
+}
+
+## Print the date.
+print_date() {
+  date
+}
+`,
+		documentationParts: []DocumentationPart{},
+	}
+
+	expectedParts := []DocumentationPart{
+		{
+			sectionType:    DocumentationPartFunction,
+			sectionContent: "\n== hello\n\nSay hello.\n\n.Arguments\n[cols=\"1,1,5\"]\n|===\n|Name |Type |Description\n\n|$1 |string |The name\n|===\n",
+			name:           "hello",
+			tags:           &taggedDocs{text: []string{"Say hello."}, arguments: [][]string{{"$1", "string", "The name"}}},
+		},
+		{
+			sectionType:    DocumentationPartFunction,
+			sectionContent: "\n== print_date\n\nPrint the date.\n",
+			name:           "print_date",
+			tags:           &taggedDocs{text: []string{"Print the date."}},
+		},
+	}
+
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	functionParts := []DocumentationPart{}
+	for _, part := range codeFile.documentationParts {
+		if part.SectionType() == DocumentationPartFunction {
+			functionParts = append(functionParts, part)
+		}
+	}
+	assert.Equal(expectedParts, functionParts, "Incorrect function docs")
+}
+
+func Test_ShouldNotUseHeaderDocsAsFunctionDocs(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		path:          "some/path",
+		name:          "functions.sh",
+		lang:          LanguageBash,
+		supportedLang: true,
+		fileContent: `#!/bin/bash
+## Header docs
+# Regular comment, not part of the docs
+## More header docs
+hello() {
+  echo "Hello"
+}
+## Print the date.
+print_date() {
+  date
+}
+`,
+		documentationParts: []DocumentationPart{},
+	}
+
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	expectedHeader := &taggedDocs{text: []string{"Header docs", "More header docs"}}
+	expectedFunctions := []string{"print_date"}
+	functions := []string{}
+	for _, part := range codeFile.documentationParts {
+		switch part.SectionType() {
+		case DocumentationPartHeader:
+			assert.Equal(expectedHeader, part.tags, "Incorrect header docs")
+		case DocumentationPartFunction:
+			functions = append(functions, part.name)
+		}
+	}
+	assert.Equal(expectedFunctions, functions, "Incorrect documented functions")
+}
+
+func Test_ShouldNotParseFunctionDocsForOtherLanguages(t *testing.T) {
+	codeFile := &CodeFile{
+		name:        "Makefile",
+		lang:        LanguageMake,
+		fileContent: "## Build the app\nbuild() {\n",
+	}
+
+	err := codeFile.Parse()
+	assert.Nil(t, err, "Error parsing documentation")
+
+	for _, part := range codeFile.documentationParts {
+		assert.NotEqual(t, DocumentationPartFunction, part.SectionType(), "Function docs should only be parsed for Bash")
+	}
+}
+
+func Test_ShouldCreateCodeFileWithLanguage(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		lang      string
+		expected  string
+		supported bool
+	}{
+		{lang: LanguageBash, expected: LanguageBash, supported: true},
+		{lang: LanguageMake, expected: LanguageMake, supported: true},
+		{lang: "go", expected: LanguageNotSupported, supported: false},
+	}
+
+	for _, test := range tests {
+		cf := NewCodeFileWithLanguage("/src/bin/deploy", test.lang)
+		assert.Equal("/src/bin", cf.Path(), "Incorrect path")
+		assert.Equal("deploy", cf.Filename(), "Incorrect filename")
+		assert.Equal(test.expected, cf.Language(), "Incorrect language")
+		assert.Equal(test.supported, cf.IsSupportedLanguage(), "Incorrect supported status")
+	}
+}
+
+func Test_ShouldHashResolvedReferences(t *testing.T) {
+	assert := assert.New(t)
+
+	chdir(t, t.TempDir())
+	createFiles(t, ".", map[string]string{
+		"src/script.sh": "## Lorem ipsum\n## @see util.sh\n",
+	})
+	hash := func() string {
+		codeFile := NewCodeFile("src/script.sh")
+		err := codeFile.ReadFileContent()
+		assert.Nil(err, "Error reading file content")
+		err = codeFile.Parse()
+		assert.Nil(err, "Error parsing documentation")
+		return codeFile.DocumentationHash()
+	}
+
+	unresolved := hash()
+	createFiles(t, ".", map[string]string{"util.sh": ""})
+	resolved := hash()
+	assert.NotEqual(unresolved, resolved, "Resolved references should change the hash")
+
+	createFiles(t, ".", map[string]string{"src/other.sh": ""})
+	assert.Equal(resolved, hash(), "Unreferenced code files should not change the hash")
+}
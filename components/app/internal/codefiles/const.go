@@ -8,11 +8,54 @@ const (
 	LanguageBash         = "sh"
 	LanguageNotSupported = "not-supported"
 
+	// DocumentationPartTitle represents the title of the documentation page.
+	DocumentationPartTitle = "title"
+
 	// DocumentationPartMetadata represents the meta information of a code file like the filename and path.
 	DocumentationPartMetadata = "meta"
 
 	// DocumentationPartHeader represents the header documentation of a code file.
 	DocumentationPartHeader = "header"
+
+	// DocumentationPartFunction represents the documentation of a single function inside a code file.
+	DocumentationPartFunction = "function"
+
+	// DocumentationPartTargets represents the table of all targets of a Makefile.
+	DocumentationPartTargets = "targets"
+
+	// DocumentationPartInstructions represents the tables of documented instructions of a Dockerfile build stage.
+	DocumentationPartInstructions = "instructions"
+
+	// DocumentationPartKeys represents the table of documented keys of a YAML file.
+	DocumentationPartKeys = "keys"
+)
+
+const (
+	// CoverageItemHeader represents the header documentation of a code file in the coverage.
+	CoverageItemHeader = "header"
+
+	// CoverageItemFunction represents a Bash function in the coverage.
+	CoverageItemFunction = "function"
+
+	// CoverageItemTarget represents a Makefile target in the coverage.
+	CoverageItemTarget = "target"
+)
+
+const (
+	// WarningNoHeaderDocs is reported for code files without header documentation.
+	WarningNoHeaderDocs = "no header documentation found"
+)
+
+const (
+	// IgnoreFilename is the name of the project specific ignore files (using gitignore semantics).
+	IgnoreFilename = ".source2adocignore"
+
+	// GitignoreFilename is the name of the ignore files used by git.
+	GitignoreFilename = ".gitignore"
+
+	// gitDir is the name of the directory (or file for worktrees and submodules) which marks the
+	// root of a git repository.
+	gitDir = ".git"
 )
 
 // TestSourceDir is the path to the test data directory for use in testcases.
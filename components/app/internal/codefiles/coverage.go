@@ -0,0 +1,45 @@
+package codefiles
+
+// CoverageItem is a part of a CodeFile which is expected to be documented, e.g. the header of the
+// file or a single Bash function.
+type CoverageItem struct {
+	Kind       string
+	Name       string
+	Documented bool
+}
+
+// Coverage returns all items of the CodeFile which are expected to be documented. Every CodeFile
+// is expected to have header documentation. Bash scripts are expected to document all functions
+// and Makefiles are expected to document all targets. The CodeFile must be parsed before.
+func (cf *CodeFile) Coverage() []CoverageItem {
+	items := []CoverageItem{
+		{Kind: CoverageItemHeader, Name: cf.Filename(), Documented: cf.hasHeaderDocs()},
+	}
+
+	switch cf.lang {
+	case LanguageBash:
+		for _, function := range cf.bashFunctions() {
+			items = append(items, CoverageItem{Kind: CoverageItemFunction, Name: function.name, Documented: len(function.docs) > 0})
+		}
+	case LanguageMake:
+		for _, target := range cf.makeTargets() {
+			items = append(items, CoverageItem{Kind: CoverageItemTarget, Name: target.name, Documented: target.description != ""})
+		}
+	}
+	return items
+}
+
+// CoveragePercent returns the percentage of documented items. Without any items, there is nothing
+// left to document and the coverage is 100%.
+func CoveragePercent(items []CoverageItem) float64 {
+	if len(items) == 0 {
+		return 100
+	}
+	documented := 0
+	for _, item := range items {
+		if item.Documented {
+			documented++
+		}
+	}
+	return float64(documented) * 100 / float64(len(items))
+}
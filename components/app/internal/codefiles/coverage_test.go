@@ -0,0 +1,80 @@
+package codefiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldListCoverageItems(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		path     string
+		content  string
+		expected []CoverageItem
+	}{
+		{
+			path:    "script.sh",
+			content: "#!/bin/bash\n## Script docs\n\n## Greet someone.\ngreet() {\n}\n\ncleanup() {\n}\n\n## Say goodbye.\nbye() { echo bye; }\n",
+			expected: []CoverageItem{
+				{Kind: CoverageItemHeader, Name: "script.sh", Documented: true},
+				{Kind: CoverageItemFunction, Name: "greet", Documented: true},
+				{Kind: CoverageItemFunction, Name: "cleanup", Documented: false},
+				{Kind: CoverageItemFunction, Name: "bye", Documented: true},
+			},
+		},
+		{
+			path:    "Makefile",
+			content: "## Build targets\n\n## Build the app\nbuild:\n\ntest: build ## Run the tests\n\nclean:\n",
+			expected: []CoverageItem{
+				{Kind: CoverageItemHeader, Name: "Makefile", Documented: true},
+				{Kind: CoverageItemTarget, Name: "build", Documented: true},
+				{Kind: CoverageItemTarget, Name: "test", Documented: true},
+				{Kind: CoverageItemTarget, Name: "clean", Documented: false},
+			},
+		},
+		{
+			path:    "build/Makefile",
+			content: "build: CFLAGS = -O2\nbuild: deps\n\n## Build the app\nbuild: lint\n\nclean:\nclean: build\n",
+			expected: []CoverageItem{
+				{Kind: CoverageItemHeader, Name: "Makefile", Documented: false},
+				{Kind: CoverageItemTarget, Name: "build", Documented: true},
+				{Kind: CoverageItemTarget, Name: "clean", Documented: false},
+			},
+		},
+		{
+			path:    "docker/Dockerfile",
+			content: "FROM alpine:latest\n",
+			expected: []CoverageItem{
+				{Kind: CoverageItemHeader, Name: "Dockerfile", Documented: false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		codeFile := NewCodeFile(test.path)
+		codeFile.fileContent = test.content
+		err := codeFile.Parse()
+		assert.Nil(err, "Error parsing documentation")
+		assert.Equal(test.expected, codeFile.Coverage(), "Incorrect coverage for "+test.path)
+	}
+}
+
+func Test_ShouldCalculateCoveragePercent(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		items    []CoverageItem
+		expected float64
+	}{
+		{items: []CoverageItem{}, expected: 100},
+		{items: []CoverageItem{{Documented: true}, {Documented: false}}, expected: 50},
+		{items: []CoverageItem{{Documented: true}, {Documented: true}, {Documented: true}, {Documented: false}}, expected: 75},
+		{items: []CoverageItem{{Documented: false}}, expected: 0},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, CoveragePercent(test.items), "Incorrect coverage percent")
+	}
+}
@@ -0,0 +1,220 @@
+package codefiles
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+)
+
+// dockerTables defines which Dockerfile instructions are documented and how the table for each
+// instruction looks like. The order of this list is the order of the tables in the docs.
+var dockerTables = []struct {
+	instruction string
+	title       string
+	header      []string
+}{
+	{instruction: "ARG", title: "Build Arguments", header: []string{"Name", "Default Value", "Description"}},
+	{instruction: "ENV", title: "Environment Variables", header: []string{"Name", "Default Value", "Description"}},
+	{instruction: "LABEL", title: "Labels", header: []string{"Name", "Value", "Description"}},
+	{instruction: "EXPOSE", title: "Ports", header: []string{"Port", "Description"}},
+	{instruction: "VOLUME", title: "Volumes", header: []string{"Path", "Description"}},
+	{instruction: "ENTRYPOINT", title: "Entrypoint", header: []string{"Command", "Description"}},
+}
+
+// dockerStage represents a build stage of a (multi-stage) Dockerfile. Instructions before the
+// first `FROM` belong to a global stage.
+type dockerStage struct {
+	heading string
+	rows    map[string][][]string
+}
+
+func newDockerStage(heading string) *dockerStage {
+	return &dockerStage{
+		heading: heading,
+		rows:    map[string][][]string{},
+	}
+}
+
+// parseDockerInstructions finds the relevant instructions (see dockerTables) of a Dockerfile and
+// returns them as tables. The description of an instruction is taken from the comments (marked
+// with `##`) immediately preceding the instruction. Each build stage is rendered as its own
+// section.
+//
+// See "Rules for the Dockerfile instruction documentation" in `docs/modules/ROOT/pages/index.adoc`.
+func (cf *CodeFile) parseDockerInstructions() []DocumentationPart {
+	stages := []*dockerStage{newDockerStage("Global")}
+	instructionDocs := []string{}
+	for _, line := range dockerLines(cf.fileContent) {
+		if text, ok := cf.docLine(line); ok {
+			instructionDocs = append(instructionDocs, text)
+			continue
+		}
+
+		instruction, args := splitDockerInstruction(line)
+		if instruction == "FROM" {
+			stages = append(stages, newDockerStage(dockerStageHeading(args)))
+		}
+
+		stage := stages[len(stages)-1]
+		description := strings.Join(instructionDocs, " ")
+		for _, row := range dockerRows(instruction, args) {
+			stage.rows[instruction] = append(stage.rows[instruction], append(row, description))
+		}
+		instructionDocs = []string{}
+	}
+
+	parts := []DocumentationPart{}
+	for _, stage := range stages {
+		if len(stage.rows) > 0 {
+			part := NewDocumentationPart(DocumentationPartInstructions, stage.render(cf.docsRenderer()))
+			part.name = stage.heading
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// dockerLines splits the content of a Dockerfile into lines. Lines ending with a backslash are
+// joined with the following line. Comments are never joined.
+func dockerLines(content string) []string {
+	lines := []string{}
+	current := ""
+	for _, line := range strings.Split(content, "\n") {
+		if current == "" && strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lines = append(lines, strings.TrimSpace(line))
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if strings.HasSuffix(trimmed, "\\") {
+			current += strings.TrimSpace(strings.TrimSuffix(trimmed, "\\")) + " "
+			continue
+		}
+		lines = append(lines, current+trimmed)
+		current = ""
+	}
+	return lines
+}
+
+// splitDockerInstruction returns the instruction (uppercase) and the arguments of a line.
+func splitDockerInstruction(line string) (string, string) {
+	instruction, args, _ := strings.Cut(strings.TrimSpace(line), " ")
+	return strings.ToUpper(instruction), strings.TrimSpace(args)
+}
+
+// dockerStageHeading returns the heading for a build stage based on the arguments of the `FROM`
+// instruction. Named stages (`FROM image AS name`) use their name, unnamed stages use the image.
+func dockerStageHeading(args string) string {
+	tokens := strings.Fields(args)
+	for i, token := range tokens {
+		if strings.EqualFold(token, "AS") && i+1 < len(tokens) {
+			return "Stage: " + tokens[i+1]
+		}
+	}
+	for _, token := range tokens {
+		if !strings.HasPrefix(token, "--") {
+			return "Stage: " + token
+		}
+	}
+	return "Stage"
+}
+
+// dockerRows translates the arguments of an instruction into table rows (without description).
+// Instructions without a table in dockerTables result in no rows.
+func dockerRows(instruction string, args string) [][]string {
+	rows := [][]string{}
+	switch instruction {
+	case "ARG", "ENV", "LABEL":
+		rows = dockerKeyValuePairs(instruction, args)
+	case "EXPOSE", "VOLUME":
+		for _, value := range dockerList(args) {
+			rows = append(rows, []string{value})
+		}
+	case "ENTRYPOINT":
+		rows = append(rows, []string{strings.Join(dockerList(args), " ")})
+	}
+	return rows
+}
+
+// dockerKeyValuePairs splits arguments like `KEY=value OTHER="some value"` into key-value pairs.
+// The legacy `ENV KEY some value` syntax and `ARG KEY` without default value are supported as well.
+func dockerKeyValuePairs(instruction string, args string) [][]string {
+	tokens := dockerTokens(args)
+	if len(tokens) == 0 {
+		return nil
+	}
+	if instruction == "ENV" && !strings.Contains(tokens[0], "=") {
+		key, value, _ := strings.Cut(args, " ")
+		return [][]string{{key, strings.TrimSpace(value)}}
+	}
+
+	pairs := [][]string{}
+	for _, token := range tokens {
+		key, value, _ := strings.Cut(token, "=")
+		pairs = append(pairs, []string{key, value})
+	}
+	return pairs
+}
+
+// dockerList returns the values of instructions supporting the JSON array syntax (e.g.
+// `VOLUME ["/data"]`) and the plain syntax (e.g. `VOLUME /data`).
+func dockerList(args string) []string {
+	if strings.HasPrefix(args, "[") {
+		values := []string{}
+		if json.Unmarshal([]byte(args), &values) == nil {
+			return values
+		}
+	}
+	return dockerTokens(args)
+}
+
+// dockerTokens splits the arguments of an instruction by whitespace. Whitespace inside quotes
+// does not split the arguments. The quotes are removed.
+func dockerTokens(args string) []string {
+	tokens := []string{}
+	current := ""
+	quote := rune(0)
+	for _, char := range args {
+		switch {
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote == 0 && (char == '"' || char == '\''):
+			quote = char
+		case quote == 0 && (char == ' ' || char == '\t'):
+			if current != "" {
+				tokens = append(tokens, current)
+			}
+			current = ""
+		default:
+			current += string(char)
+		}
+	}
+	if current != "" {
+		tokens = append(tokens, current)
+	}
+	return tokens
+}
+
+// render renders all documented instructions of the stage as a section with one table per
+// instruction.
+func (stage *dockerStage) render(renderer render.Renderer) string {
+	content := "\n"
+	content += renderer.Heading(1, stage.heading)
+	for _, table := range dockerTables {
+		rows, ok := stage.rows[table.instruction]
+		if !ok {
+			continue
+		}
+
+		cols := make([]int, len(table.header))
+		for i := range cols {
+			cols[i] = 2
+		}
+		cols[len(cols)-1] = 5
+
+		content += "\n"
+		content += renderer.Table(render.Table{Title: table.title, Cols: cols, Header: table.header, Rows: rows})
+	}
+	return content
+}
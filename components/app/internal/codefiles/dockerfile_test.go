@@ -0,0 +1,138 @@
+package codefiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldJoinDockerLines(t *testing.T) {
+	assert := assert.New(t)
+
+	content := "## Some labels\nLABEL a=b \\\n    c=d\n  # comment \\\nRUN echo"
+	expected := []string{"## Some labels", "LABEL a=b c=d", "# comment \\", "RUN echo"}
+
+	assert.Equal(expected, dockerLines(content), "Incorrect lines")
+}
+
+func Test_ShouldTranslateDockerRows(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		instruction string
+		args        string
+		expected    [][]string
+	}{
+		{instruction: "ARG", args: "VERSION", expected: [][]string{{"VERSION", ""}}},
+		{instruction: "ARG", args: "VERSION=1.0.0", expected: [][]string{{"VERSION", "1.0.0"}}},
+		{instruction: "ENV", args: `A=1 B="some value"`, expected: [][]string{{"A", "1"}, {"B", "some value"}}},
+		{instruction: "ENV", args: "LEGACY some value", expected: [][]string{{"LEGACY", "some value"}}},
+		{instruction: "LABEL", args: `org.opencontainers.image.title="source2adoc"`, expected: [][]string{{"org.opencontainers.image.title", "source2adoc"}}},
+		{instruction: "EXPOSE", args: "80 443/tcp", expected: [][]string{{"80"}, {"443/tcp"}}},
+		{instruction: "VOLUME", args: `["/data", "/logs"]`, expected: [][]string{{"/data"}, {"/logs"}}},
+		{instruction: "VOLUME", args: "/data", expected: [][]string{{"/data"}}},
+		{instruction: "ENTRYPOINT", args: `["/app", "--serve"]`, expected: [][]string{{"/app --serve"}}},
+		{instruction: "ENTRYPOINT", args: "/app --serve", expected: [][]string{{"/app --serve"}}},
+		{instruction: "RUN", args: "echo hello", expected: [][]string{}},
+	}
+
+	for _, test := range tests {
+		rows := dockerRows(test.instruction, test.args)
+		assert.Equal(test.expected, rows, "Incorrect rows for: "+test.instruction+" "+test.args)
+	}
+}
+
+func Test_ShouldIdentifyDockerStageHeading(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("Stage: build", dockerStageHeading("golang:1.22 AS build"))
+	assert.Equal("Stage: run", dockerStageHeading("--platform=linux/amd64 scratch as run"))
+	assert.Equal("Stage: alpine:3.20", dockerStageHeading("alpine:3.20"))
+	assert.Equal("Stage: alpine:3.20", dockerStageHeading("--platform=linux/amd64 alpine:3.20"))
+}
+
+func Test_ShouldParseDockerInstructions(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		name: "Dockerfile",
+		lang: LanguageDockerfile,
+		fileContent: `## Header docs
+
+## The Go version.
+ARG GO_VERSION=1.22
+
+FROM golang:${GO_VERSION} AS build
+RUN go build .
+
+FROM scratch AS run
+
+## The port of the web server.
+## Defaults to 8080.
+EXPOSE 8080
+ENV MODE=prod
+ENTRYPOINT ["/app"]
+`,
+		documentationParts: []DocumentationPart{},
+	}
+
+	expectedParts := []DocumentationPart{
+		{
+			sectionType: DocumentationPartInstructions,
+			name:        "Global",
+			sectionContent: `
+== Global
+
+.Build Arguments
+[cols="2,2,5"]
+|===
+|Name |Default Value |Description
+
+|GO_VERSION |1.22 |The Go version.
+|===
+`,
+		},
+		{
+			sectionType: DocumentationPartInstructions,
+			name:        "Stage: run",
+			sectionContent: `
+== Stage: run
+
+.Environment Variables
+[cols="2,2,5"]
+|===
+|Name |Default Value |Description
+
+|MODE |prod |
+|===
+
+.Ports
+[cols="2,5"]
+|===
+|Port |Description
+
+|8080 |The port of the web server. Defaults to 8080.
+|===
+
+.Entrypoint
+[cols="2,5"]
+|===
+|Command |Description
+
+|/app |
+|===
+`,
+		},
+	}
+
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	instructionParts := []DocumentationPart{}
+	for _, part := range codeFile.documentationParts {
+		if part.SectionType() == DocumentationPartInstructions {
+			instructionParts = append(instructionParts, part)
+		}
+	}
+	assert.Equal(expectedParts, instructionParts, "Incorrect instruction docs")
+}
@@ -5,6 +5,17 @@ package codefiles
 type DocumentationPart struct {
 	sectionType    string
 	sectionContent string
+	name           string
+	tags           *taggedDocs
+}
+
+// NewDocumentationPart acts as a constructor for a new DocumentationPart instance. It allows
+// the BlockParsers of all languages to contribute their parts to the documentation page.
+func NewDocumentationPart(sectionType string, sectionContent string) DocumentationPart {
+	return DocumentationPart{
+		sectionType:    sectionType,
+		sectionContent: sectionContent,
+	}
 }
 
 // SectionContent returns the type of the DocumentationPart to distinguish between header
@@ -18,3 +29,9 @@ func (part *DocumentationPart) SectionType() string {
 func (part *DocumentationPart) SectionContent() string {
 	return part.sectionContent
 }
+
+// Name returns the name of the documented element (e.g. the function name or the Dockerfile build
+// stage). Parts which do not document a single element have no name.
+func (part *DocumentationPart) Name() string {
+	return part.name
+}
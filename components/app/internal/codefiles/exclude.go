@@ -0,0 +1,122 @@
+package codefiles
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// globPattern represents a single entry of a pattern list. Negated patterns (starting with `!`)
+// revert the result of a previous pattern (e.g. re-include files which are excluded by a
+// previous exclude pattern).
+type globPattern struct {
+	glob   string
+	negate bool
+}
+
+// pathMatcher decides which paths match a list of patterns (e.g. the excludes or the includes).
+// All patterns are glob patterns (including `**`) relative to the source directory. Absolute
+// paths inside the source directory are translated into relative patterns.
+type pathMatcher struct {
+	srcDir   string
+	patterns []globPattern
+}
+
+// newPathMatcher creates a new pathMatcher for the given source directory and patterns.
+func newPathMatcher(srcDir string, patterns []string) (*pathMatcher, error) {
+	matcher := &pathMatcher{
+		srcDir:   srcDir,
+		patterns: []globPattern{},
+	}
+
+	for _, pattern := range patterns {
+		negate := strings.HasPrefix(pattern, "!")
+		glob := matcher.relativeGlob(strings.TrimPrefix(pattern, "!"))
+		if !doublestar.ValidatePattern(glob) {
+			return nil, fmt.Errorf("invalid pattern: %s", pattern)
+		}
+		matcher.patterns = append(matcher.patterns, globPattern{glob: glob, negate: negate})
+	}
+	return matcher, nil
+}
+
+// relativeGlob translates a pattern into a slash-separated glob relative to the source directory.
+// The source directory is resolved to an absolute path first, so absolute patterns work with
+// relative source directories as well. Just like in .gitignore files, relative patterns without
+// slash (apart from a trailing one) match at any depth, e.g. `vendor` matches `sub/vendor` as
+// well. All other patterns are anchored at the source directory.
+func (matcher *pathMatcher) relativeGlob(pattern string) string {
+	if filepath.IsAbs(pattern) {
+		if rel, err := relativeToDir(matcher.srcDir, pattern); err == nil {
+			pattern = rel
+		}
+	} else if !strings.Contains(strings.TrimSuffix(filepath.ToSlash(pattern), "/"), "/") {
+		return "**/" + filepath.ToSlash(filepath.Clean(pattern))
+	}
+	return filepath.ToSlash(filepath.Clean(pattern))
+}
+
+// relativeToDir returns the absolute path relative to the given (absolute or relative) directory.
+func relativeToDir(dir string, path string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(abs, path)
+}
+
+// isEmpty returns true if the matcher has no patterns.
+func (matcher *pathMatcher) isEmpty() bool {
+	return len(matcher.patterns) == 0
+}
+
+// hasNegations returns true if any pattern is negated.
+func (matcher *pathMatcher) hasNegations() bool {
+	for _, pattern := range matcher.patterns {
+		if pattern.negate {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns true if the path matches the patterns. A pattern matches a path if it matches
+// the path itself or any of its parent directories. If multiple patterns match, the last one wins.
+func (matcher *pathMatcher) matches(path string) bool {
+	rel, err := filepath.Rel(matcher.srcDir, path)
+	if err != nil {
+		return false
+	}
+
+	candidates := pathWithParents(filepath.ToSlash(rel))
+	matches := false
+	for _, pattern := range matcher.patterns {
+		if pattern.matchesAny(candidates) {
+			matches = !pattern.negate
+		}
+	}
+	return matches
+}
+
+// matchesAny returns true if the pattern matches any of the given paths.
+func (pattern globPattern) matchesAny(paths []string) bool {
+	for _, path := range paths {
+		if doublestar.MatchUnvalidated(pattern.glob, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathWithParents returns all parent directories of a slash-separated path and the path itself,
+// e.g. `a`, `a/b` and `a/b/c.sh` for `a/b/c.sh`.
+func pathWithParents(path string) []string {
+	segments := strings.Split(path, "/")
+	paths := make([]string, 0, len(segments))
+	for i := range segments {
+		paths = append(paths, strings.Join(segments[:i+1], "/"))
+	}
+	return paths
+}
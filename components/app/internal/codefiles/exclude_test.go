@@ -0,0 +1,69 @@
+package codefiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldListPathWithParents(t *testing.T) {
+	expected := []string{"a", "a/b", "a/b/c.sh"}
+	assert.Equal(t, expected, pathWithParents("a/b/c.sh"), "Incorrect paths")
+}
+
+func Test_ShouldMatchPathPatterns(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		patterns []string
+		path     string
+		matches  bool
+	}{
+		{patterns: []string{"test"}, path: "/src/test/script.sh", matches: true},
+		{patterns: []string{"test"}, path: "/src/latest/script.sh", matches: false},
+		{patterns: []string{"test"}, path: "/src/contest.sh", matches: false},
+		{patterns: []string{"test/"}, path: "/src/test/script.sh", matches: true},
+		{patterns: []string{"/src/test"}, path: "/src/test/script.sh", matches: true},
+		{patterns: []string{"**/*.bak.sh"}, path: "/src/lib/old.bak.sh", matches: true},
+		{patterns: []string{"**/*.bak.sh"}, path: "/src/old.bak.sh", matches: true},
+		{patterns: []string{"**/*.bak.sh"}, path: "/src/lib/old.sh", matches: false},
+		{patterns: []string{"vendor/**"}, path: "/src/vendor/lib/script.sh", matches: true},
+		{patterns: []string{"*.yml"}, path: "/src/config/app.yml", matches: true},
+		{patterns: []string{"*.yml"}, path: "/src/app.yml", matches: true},
+		{patterns: []string{"config/*.yml"}, path: "/src/config/app.yml", matches: true},
+		{patterns: []string{"config/*.yml"}, path: "/src/lib/config/app.yml", matches: false},
+		{patterns: []string{"vendor"}, path: "/src/vendor/lib.sh", matches: true},
+		{patterns: []string{"vendor"}, path: "/src/sub/vendor/lib.sh", matches: true},
+		{patterns: []string{"vendor/"}, path: "/src/sub/vendor/lib.sh", matches: true},
+		{patterns: []string{"sub/vendor"}, path: "/src/a/sub/vendor/lib.sh", matches: false},
+		{patterns: []string{"*.sh", "!keep.sh"}, path: "/src/keep.sh", matches: false},
+		{patterns: []string{"*.sh", "!keep.sh"}, path: "/src/drop.sh", matches: true},
+		{patterns: []string{"!keep.sh", "*.sh"}, path: "/src/keep.sh", matches: true},
+		{patterns: []string{"lib/**", "!lib/**/keep.sh"}, path: "/src/lib/a/keep.sh", matches: false},
+	}
+
+	for _, test := range tests {
+		matcher, err := newPathMatcher("/src", test.patterns)
+		assert.Nil(err, "Error creating path matcher")
+		assert.Equal(test.matches, matcher.matches(test.path), "Incorrect match status for: "+test.path)
+	}
+}
+
+func Test_ShouldMatchAbsolutePatternsWithRelativeSourceDir(t *testing.T) {
+	assert := assert.New(t)
+
+	wd, err := os.Getwd()
+	assert.Nil(err, "Error getting working directory")
+	matcher, err := newPathMatcher("src", []string{filepath.Join(wd, "src/test")})
+	assert.Nil(err, "Error creating path matcher")
+
+	assert.True(matcher.matches("src/test/script.sh"), "Absolute pattern should match")
+	assert.False(matcher.matches("src/other/script.sh"), "Absolute pattern should not match other paths")
+}
+
+func Test_ShouldFailForInvalidPathPatterns(t *testing.T) {
+	_, err := newPathMatcher("/src", []string{"[invalid"})
+	assert.NotNil(t, err, "Invalid pattern should be reported")
+}
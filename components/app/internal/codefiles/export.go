@@ -0,0 +1,133 @@
+package codefiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+)
+
+// ExportFilename is the name of the file containing the JSON export of the parsed documentation.
+const ExportFilename = "source2adoc.json"
+
+// Export represents the parsed documentation of all code files. It is serialized into a single
+// JSON document to feed the documentation into other tools (e.g. a search indexer).
+type Export struct {
+	Files []ExportedFile `json:"files"`
+}
+
+// ExportedFile represents a single code file with all its DocumentationParts.
+type ExportedFile struct {
+	Path     string         `json:"path"`
+	Filename string         `json:"filename"`
+	Language string         `json:"language"`
+	Page     string         `json:"page"`
+	Parts    []ExportedPart `json:"parts"`
+}
+
+// ExportedPart represents a single DocumentationPart. The content is rendered in the output
+// format of the code file. Header docs and function docs contain their parsed tags as well.
+type ExportedPart struct {
+	Type    string        `json:"type"`
+	Name    string        `json:"name,omitempty"`
+	Content string        `json:"content"`
+	Tags    *ExportedTags `json:"tags,omitempty"`
+}
+
+// ExportedTags represents the JavaDoc-style tags of a documentation block.
+type ExportedTags struct {
+	Text      []string           `json:"text"`
+	Arguments []ExportedArgument `json:"arguments"`
+	Metadata  []ExportedMetadata `json:"metadata"`
+	See       []string           `json:"see"`
+}
+
+// ExportedArgument represents an `@arg` tag.
+type ExportedArgument struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// ExportedMetadata represents a metadata tag like `@author` or `@since`.
+type ExportedMetadata struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// NewExport creates the Export for the given code files. The code files must be parsed already.
+func NewExport(files []*CodeFile) *Export {
+	export := &Export{Files: []ExportedFile{}}
+	for _, file := range files {
+		export.Files = append(export.Files, file.export())
+	}
+	return export
+}
+
+// export translates the CodeFile into its exported representation.
+func (cf *CodeFile) export() ExportedFile {
+	exported := ExportedFile{
+		Path:     cf.path,
+		Filename: cf.name,
+		Language: cf.lang,
+		Page:     cf.documentationPage(),
+		Parts:    []ExportedPart{},
+	}
+	for _, part := range cf.documentationParts {
+		exported.Parts = append(exported.Parts, ExportedPart{
+			Type:    part.sectionType,
+			Name:    part.name,
+			Content: part.sectionContent,
+			Tags:    part.tags.export(),
+		})
+	}
+	return exported
+}
+
+// export translates the tags into their exported representation. Parts without tags are exported
+// without tags.
+func (docs *taggedDocs) export() *ExportedTags {
+	if docs == nil {
+		return nil
+	}
+
+	tags := &ExportedTags{
+		Text:      append([]string{}, docs.text...),
+		Arguments: []ExportedArgument{},
+		Metadata:  []ExportedMetadata{},
+		See:       []string{},
+	}
+	for _, argument := range docs.arguments {
+		tags.Arguments = append(tags.Arguments, ExportedArgument{Name: argument[0], Type: argument[1], Description: argument[2]})
+	}
+	for _, metadata := range docs.metadata {
+		tags.Metadata = append(tags.Metadata, ExportedMetadata{Name: metadata[0], Value: metadata[1]})
+	}
+	for _, see := range docs.see {
+		tags.See = append(tags.See, see.target)
+	}
+	return tags
+}
+
+// ExportFile returns the file containing the Export as JSON document without writing it. The
+// file is the ExportFilename inside the output directory.
+func (export *Export) ExportFile(outputDir string) (*output.File, error) {
+	content, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize export: %v", err)
+	}
+
+	source := fmt.Sprintf("%d files", len(export.Files))
+	return output.NewFile(source, filepath.Join(outputDir, ExportFilename), string(content)+"\n"), nil
+}
+
+// WriteExportFile writes the Export as JSON document to the ExportFilename inside the output
+// directory.
+func (export *Export) WriteExportFile(outputDir string) error {
+	file, err := export.ExportFile(outputDir)
+	if err != nil {
+		return err
+	}
+	return file.Write()
+}
@@ -0,0 +1,83 @@
+package codefiles
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldExportParsedDocumentation(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		path:          "src",
+		name:          "script.sh",
+		lang:          LanguageBash,
+		supportedLang: true,
+		fileContent: `#!/bin/bash
+## Lorem ipsum dolor sit amet.
+## @author Sebastian Sommerfeld
+## @see lib/util.sh
+
+## Greet someone.
+## @arg $1 string The name
+function greet() {
+  echo "Hello $1"
+}
+`,
+		documentationParts: []DocumentationPart{},
+	}
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	export := NewExport([]*CodeFile{codeFile})
+	assert.Len(export.Files, 1, "Incorrect number of files")
+
+	file := export.Files[0]
+	assert.Equal("src", file.Path, "Incorrect path")
+	assert.Equal("script.sh", file.Filename, "Incorrect filename")
+	assert.Equal(LanguageBash, file.Language, "Incorrect language")
+	assert.Equal("src/script-sh.adoc", file.Page, "Incorrect page")
+	assert.Len(file.Parts, 4, "Incorrect number of parts")
+
+	assert.Equal(DocumentationPartTitle, file.Parts[0].Type, "Incorrect part type")
+	assert.Equal("= script.sh\n", file.Parts[0].Content, "Incorrect title")
+	assert.Equal(DocumentationPartMetadata, file.Parts[1].Type, "Incorrect part type")
+	assert.Nil(file.Parts[1].Tags, "Metadata should have no tags")
+
+	header := file.Parts[2]
+	assert.Equal(DocumentationPartHeader, header.Type, "Incorrect part type")
+	assert.Equal([]string{"Lorem ipsum dolor sit amet."}, header.Tags.Text, "Incorrect text")
+	assert.Equal([]ExportedMetadata{{Name: "Author", Value: "Sebastian Sommerfeld"}}, header.Tags.Metadata, "Incorrect metadata")
+	assert.Equal([]string{"lib/util.sh"}, header.Tags.See, "Incorrect see tags")
+
+	function := file.Parts[3]
+	assert.Equal(DocumentationPartFunction, function.Type, "Incorrect part type")
+	assert.Equal("greet", function.Name, "Incorrect name")
+	assert.Equal([]ExportedArgument{{Name: "$1", Type: "string", Description: "The name"}}, function.Tags.Arguments, "Incorrect arguments")
+	assert.Contains(function.Content, "== greet", "Content should be rendered")
+}
+
+func Test_ShouldWriteExportFile(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := NewCodeFile("src/script.sh")
+	codeFile.fileContent = "## Lorem ipsum\n"
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	outputDir := filepath.Join(t.TempDir(), "docs")
+	err = NewExport([]*CodeFile{codeFile}).WriteExportFile(outputDir)
+	assert.Nil(err, "Error writing export file")
+
+	content, err := os.ReadFile(filepath.Join(outputDir, ExportFilename))
+	assert.Nil(err, "Error reading export file")
+
+	export := &Export{}
+	err = json.Unmarshal(content, export)
+	assert.Nil(err, "Export file should contain valid JSON")
+	assert.Equal(*NewExport([]*CodeFile{codeFile}), *export, "Incorrect export")
+}
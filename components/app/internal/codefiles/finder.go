@@ -1,62 +1,218 @@
 package codefiles
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 )
 
 // CodeFileFinder is responsible for finding code files in a given directory.
 type CodeFileFinder struct {
-	srcDir  string
-	exclude []string
+	srcDir    string
+	include   []string
+	exclude   []string
+	languages []string
+	gitignore bool
 }
 
 // NewFinder creates a new CodeFileFinder instance.
 func NewFinder(srcDir string) *CodeFileFinder {
 	return &CodeFileFinder{
-		srcDir:  srcDir,
-		exclude: []string{},
+		srcDir:    srcDir,
+		include:   []string{},
+		exclude:   []string{},
+		languages: []string{},
 	}
 }
 
-// SetExcludes sets the list of files and/or folders to exclude when generating documentation.
+// SetIncludes sets the list of files and/or folders to consider when generating documentation.
+// If the list is empty, all files are considered. The includes are glob patterns relative to
+// srcDir (just like the excludes). Excludes are applied to the included files.
+func (finder *CodeFileFinder) SetIncludes(includes []string) {
+	finder.include = includes
+}
+
+// SetExcludes sets the list of files and/or folders to exclude when generating documentation. The
+// excludes are glob patterns relative to srcDir. Patterns starting with `!` re-include files.
 func (finder *CodeFileFinder) SetExcludes(excludes []string) {
 	finder.exclude = excludes
 }
 
+// SetLanguageMappings sets the list of explicit language assignments like `bin/deploy=sh`. The
+// mappings take precedence over the language identified from the filename, which allows
+// documenting files without a recognized suffix (e.g. extensionless executables).
+func (finder *CodeFileFinder) SetLanguageMappings(mappings []string) {
+	finder.languages = mappings
+}
+
+// SetGitignore enables or disables respecting the .gitignore files on all levels of srcDir. The
+// .source2adocignore files are always respected.
+func (finder *CodeFileFinder) SetGitignore(enabled bool) {
+	finder.gitignore = enabled
+}
+
 // FindSourceCodeFiles lists all files in srcDir and all subfolders. It returns a list of supported code files.
-// All paths from the exclude (with and without filename) are not part of the result.
+// Only paths matching the include patterns (if any) are part of the result. All paths matching the
+// exclude patterns (see pathMatcher) or the ignore files (see ignoreFiles) are not part of the result.
+//
+// Files and folders which cannot be inspected do not stop the search. In this case, the result
+// contains all other code files and the returned error joins the errors of all failed paths.
 func (finder *CodeFileFinder) FindSourceCodeFiles() ([]*CodeFile, error) {
-	var files []*CodeFile
+	files := []*CodeFile{}
+	err := finder.WalkSourceCodeFiles(func(file *CodeFile) {
+		files = append(files, file)
+	})
+	return files, err
+}
 
-	err := filepath.Walk(finder.srcDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return fmt.Errorf("failed to walk the filesystem: %v", err)
-		}
+// WalkSourceCodeFiles searches srcDir just like FindSourceCodeFiles, but passes each code file to
+// the found function as soon as it is found instead of collecting all code files. This way, the
+// code files can be processed while the search is still running.
+func (finder *CodeFileFinder) WalkSourceCodeFiles(found func(file *CodeFile)) error {
+	rules, err := finder.newFinderRules()
+	if err != nil {
+		return err
+	}
+	rules.found = found
 
-		for _, exclude := range finder.exclude {
-			if strings.Contains(path, exclude) {
-				return nil
-			}
-		}
+	err = filepath.Walk(finder.srcDir, rules.visit)
+	if err != nil {
+		return fmt.Errorf("failed to list files: %w", err)
+	}
+	return errors.Join(rules.errors...)
+}
 
-		if info.IsDir() {
-			return nil
-		}
+// finderRules combines all rules deciding which files are part of the result of the finder. The
+// rules pass the found files to the found function and collect the errors of all paths which
+// cannot be inspected.
+type finderRules struct {
+	srcDir    string
+	includes  *pathMatcher
+	excludes  *pathMatcher
+	languages languageMappings
+	ignores   *ignoreFiles
+	found     func(file *CodeFile)
+	errors    []error
+}
 
-		code := NewCodeFile(path)
-		if code.IsSupportedLanguage() {
-			files = append(files, code)
-		}
+func (finder *CodeFileFinder) newFinderRules() (*finderRules, error) {
+	includes, err := newPathMatcher(finder.srcDir, finder.include)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read includes: %w", err)
+	}
+	excludes, err := newPathMatcher(finder.srcDir, finder.exclude)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read excludes: %w", err)
+	}
+	languages, err := newLanguageMappings(finder.srcDir, finder.languages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read language mappings: %w", err)
+	}
+
+	ignores := newIgnoreFiles(finder.gitignore)
+	err = ignores.loadAncestors(finder.srcDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &finderRules{
+		srcDir:    finder.srcDir,
+		includes:  includes,
+		excludes:  excludes,
+		languages: languages,
+		ignores:   ignores,
+	}, nil
+}
 
+// visit is called for each file and directory in srcDir. Paths which cannot be inspected are
+// recorded as errors and skipped, so the remaining files are found anyway.
+func (rules *finderRules) visit(path string, info os.FileInfo, err error) error {
+	if err != nil {
+		rules.errors = append(rules.errors, fmt.Errorf("%s: failed to walk the filesystem: %v", path, err))
 		return nil
-	})
+	}
 
+	if info.IsDir() {
+		err = rules.visitDir(path)
+		if err != nil && err != filepath.SkipDir {
+			rules.errors = append(rules.errors, fmt.Errorf("%s: %v", path, err))
+			return filepath.SkipDir
+		}
+		return err
+	}
+
+	code, err := rules.codeFile(path, info)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list files: %w", err)
+		rules.errors = append(rules.errors, fmt.Errorf("%s: %v", path, err))
+		return nil
 	}
+	if code != nil {
+		rules.found(code)
+	}
+	return nil
+}
+
+// visitDir decides if a directory is skipped and loads the ignore files of the directory. The
+// `.git` directory is always skipped, because it contains no code files of the project (e.g. the
+// samples in `.git/hooks`).
+func (rules *finderRules) visitDir(path string) error {
+	if path != rules.srcDir {
+		if filepath.Base(path) == gitDir || rules.ignores.isIgnored(path, true) {
+			return filepath.SkipDir
+		}
+		// Excluded directories can only be skipped if no files are re-included later
+		if rules.excludes.matches(path) && !rules.excludes.hasNegations() {
+			return filepath.SkipDir
+		}
+	}
+	return rules.ignores.load(path)
+}
 
-	return files, nil
+// codeFile returns the CodeFile for the given path or nil if the file is skipped or written in
+// an unsupported language. The language is taken from the language mappings, the filename or
+// the shebang (in this order). Only the files which are shebang candidates (see isShebangCandidate)
+// are opened to read the shebang. Files whose shebang cannot be read are treated as unsupported.
+func (rules *finderRules) codeFile(path string, info os.FileInfo) (*CodeFile, error) {
+	if !rules.includes.isEmpty() && !rules.includes.matches(path) {
+		return nil, nil
+	}
+	if rules.excludes.matches(path) || rules.ignores.isIgnored(path, false) {
+		return nil, nil
+	}
+
+	if lang, ok := rules.languages.languageOf(path); ok {
+		return NewCodeFileWithLanguage(path, lang), nil
+	}
+	code := NewCodeFile(path)
+	if code.IsSupportedLanguage() {
+		return code, nil
+	}
+
+	if !isShebangCandidate(path, info) {
+		return nil, nil
+	}
+	lang, supported, err := identifyLanguageByShebang(path)
+	if err != nil || !supported {
+		return nil, nil
+	}
+	return NewCodeFileWithLanguage(path, lang), nil
+}
+
+// isShebangCandidate returns true if the file might be a script identified by its shebang. Only
+// regular files (or symlinks to regular files) without extension or with the executable bit set
+// are candidates. Opening other files blocks (e.g. named pipes) or fails (e.g. symlinks to
+// directories), and opening every file with an unknown extension is too expensive.
+func isShebangCandidate(path string, info os.FileInfo) bool {
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Stat(path)
+		if err != nil {
+			return false
+		}
+		info = target
+	}
+	if !info.Mode().IsRegular() {
+		return false
+	}
+	return filepath.Ext(path) == "" || info.Mode().Perm()&0111 != 0
 }
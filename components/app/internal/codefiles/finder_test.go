@@ -1,7 +1,9 @@
 package codefiles
 
 import (
+	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -92,3 +94,128 @@ func Test_ShouldFindSourceCodeFilesWithoutExcludes(t *testing.T) {
 		assert.Contains(files, expectedFile, "Expected file not found")
 	}
 }
+
+// Test_ShouldFindSourceCodeFilesWithGlobExcludes tests the case where the exclude list contains
+// glob patterns and negations. The expected result is that the finder should not return the
+// files matching the patterns except for the re-included files.
+func Test_ShouldFindSourceCodeFilesWithGlobExcludes(t *testing.T) {
+	assert := assert.New(t)
+
+	exclude := []string{
+		"good/**/Dockerfile*",
+		"!good/docker/Dockerfile.app",
+		"**/*.sh",
+		"good/yaml",
+	}
+
+	expectedFiles := []*CodeFile{
+		NewCodeFile(filepath.Join(TestSourceDir, "good/docker/Dockerfile.app")),
+		NewCodeFile(filepath.Join(TestSourceDir, "good/Makefile")),
+		NewCodeFile(filepath.Join(TestSourceDir, "good/Vagrantfile")),
+	}
+
+	finder := NewFinder(TestSourceDir)
+	finder.SetExcludes(exclude)
+
+	files, err := finder.FindSourceCodeFiles()
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(len(expectedFiles), len(files), "Should return the expected number of files")
+
+	for _, expectedFile := range expectedFiles {
+		assert.Contains(files, expectedFile, "Expected file not found")
+	}
+}
+
+// Test_ShouldContinueAfterUninspectablePaths tests the case where a folder cannot be inspected
+// (because its ignore file cannot be read). The expected result is that the finder returns all
+// other files together with an error describing the failed folder.
+func Test_ShouldContinueAfterUninspectablePaths(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"broken/" + IgnoreFilename + "/placeholder": "",
+		"broken/script.sh":                          "",
+		"good/script.sh":                            "",
+	})
+
+	files, err := NewFinder(dir).FindSourceCodeFiles()
+	assert.ErrorContains(err, filepath.Join(dir, "broken"), "Error should contain the failed path")
+	assert.Equal([]*CodeFile{NewCodeFile(filepath.Join(dir, "good/script.sh"))}, files, "Other files should be found")
+
+	files, err = NewFinder(filepath.Join(dir, "missing")).FindSourceCodeFiles()
+	assert.NotNil(err, "Missing source dir should be reported")
+	assert.Empty(files, "No files should be found")
+}
+
+// Test_ShouldOnlySniffRegularFiles tests the case where the source directory contains files
+// without a known filename which are no regular files. The expected result is that named pipes
+// and symlinks to directories are skipped without blocking or errors, while symlinks to scripts
+// are found by their shebang.
+func Test_ShouldOnlySniffRegularFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"lib/deploy": "#!/bin/bash\n",
+	})
+	err := syscall.Mkfifo(filepath.Join(dir, "pipe"), 0644)
+	assert.Nil(err, "Error creating named pipe")
+	err = os.Symlink(filepath.Join(dir, "lib"), filepath.Join(dir, "libdir"))
+	assert.Nil(err, "Error creating symlink")
+	err = os.Symlink(filepath.Join(dir, "lib/deploy"), filepath.Join(dir, "deploy"))
+	assert.Nil(err, "Error creating symlink")
+
+	files, err := NewFinder(dir).FindSourceCodeFiles()
+	assert.Nil(err, "Error finding code files")
+	expected := []*CodeFile{
+		NewCodeFileWithLanguage(filepath.Join(dir, "deploy"), LanguageBash),
+		NewCodeFileWithLanguage(filepath.Join(dir, "lib/deploy"), LanguageBash),
+	}
+	assert.Equal(expected, files, "Only regular files should be sniffed")
+}
+
+// Test_ShouldOnlySniffFilesWithoutExtensionOrExecutableBit tests the case where files with an
+// unknown extension start with a shebang. The expected result is that only executable files are
+// opened to read the shebang, while files without extension are always sniffed.
+func Test_ShouldOnlySniffFilesWithoutExtensionOrExecutableBit(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"deploy":    "#!/bin/bash\n",
+		"notes.txt": "#!/bin/bash\n",
+		"run.cgi":   "#!/bin/bash\n",
+	})
+	err := os.Chmod(filepath.Join(dir, "run.cgi"), 0755)
+	assert.Nil(err, "Error making file executable")
+
+	files, err := NewFinder(dir).FindSourceCodeFiles()
+	assert.Nil(err, "Error finding code files")
+	expected := []*CodeFile{
+		NewCodeFileWithLanguage(filepath.Join(dir, "deploy"), LanguageBash),
+		NewCodeFileWithLanguage(filepath.Join(dir, "run.cgi"), LanguageBash),
+	}
+	assert.Equal(expected, files, "Only files without extension or with executable bit should be sniffed")
+}
+
+// Test_ShouldSkipGitDir tests the case where the source directory is the root of a git
+// repository. The expected result is that no code files are found inside the `.git` directory
+// (e.g. the hook samples).
+func Test_ShouldSkipGitDir(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		".git/hooks/pre-commit.sample": "#!/bin/sh\n",
+		".git/hooks/post-update":       "#!/bin/sh\n",
+		".git/config.yml":              "key: value\n",
+		"script.sh":                    "",
+	})
+	err := os.Chmod(filepath.Join(dir, ".git/hooks/pre-commit.sample"), 0755)
+	assert.Nil(err, "Error making file executable")
+
+	files, err := NewFinder(dir).FindSourceCodeFiles()
+	assert.Nil(err, "Error finding code files")
+	assert.Equal([]*CodeFile{NewCodeFile(filepath.Join(dir, "script.sh"))}, files, "No code files should be found in .git")
+}
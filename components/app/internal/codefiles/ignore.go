@@ -0,0 +1,197 @@
+package codefiles
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ignoreRule represents a single pattern from an ignore file. The pattern is relative to the
+// directory containing the ignore file.
+type ignoreRule struct {
+	base    string
+	glob    string
+	negate  bool
+	dirOnly bool
+}
+
+// ignoreFiles holds the rules of all ignore files found in the source directory. The ignore
+// files follow the gitignore semantics. The IgnoreFilename is always respected, the
+// GitignoreFilename only if enabled. All rules use absolute base directories, so rules from
+// directories above a relative source directory match as well.
+type ignoreFiles struct {
+	gitignore bool
+	rules     []ignoreRule
+}
+
+func newIgnoreFiles(gitignore bool) *ignoreFiles {
+	return &ignoreFiles{
+		gitignore: gitignore,
+		rules:     []ignoreRule{},
+	}
+}
+
+// load reads the ignore files from the given directory (if present).
+func (ignores *ignoreFiles) load(dir string) error {
+	filenames := []string{IgnoreFilename}
+	if ignores.gitignore {
+		filenames = []string{GitignoreFilename, IgnoreFilename}
+	}
+
+	for _, filename := range filenames {
+		err := ignores.loadFile(dir, filename)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadAncestors reads the GitignoreFilename of all directories above the given directory up to
+// the root of the git repository (the directory containing `.git`), just like git does. Outside
+// of a git repository, nothing is loaded.
+func (ignores *ignoreFiles) loadAncestors(dir string) error {
+	if !ignores.gitignore {
+		return nil
+	}
+
+	ancestors, err := repositoryAncestors(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read ignore file: %v", err)
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		err = ignores.loadFile(ancestors[i], GitignoreFilename)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repositoryAncestors returns the absolute paths of all directories above the given directory up
+// to the root of the git repository, nearest first. If the directory itself is the root of the
+// repository or is no part of a repository, no directories are returned.
+func repositoryAncestors(dir string) ([]string, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors := []string{}
+	for !isRepositoryRoot(current) {
+		parent := filepath.Dir(current)
+		if parent == current {
+			return []string{}, nil
+		}
+		ancestors = append(ancestors, parent)
+		current = parent
+	}
+	return ancestors, nil
+}
+
+// isRepositoryRoot returns true if the directory contains `.git` (a directory or a file, which is
+// used by worktrees and submodules).
+func isRepositoryRoot(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, gitDir))
+	return err == nil
+}
+
+// loadFile reads the rules from a single ignore file. Missing files are skipped.
+func (ignores *ignoreFiles) loadFile(dir string, filename string) error {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read ignore file: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, filename))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read ignore file: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		rule, ok := parseIgnoreRule(base, scanner.Text())
+		if ok {
+			ignores.rules = append(ignores.rules, rule)
+		}
+	}
+	return scanner.Err()
+}
+
+// parseIgnoreRule translates a line of an ignore file into a rule. Empty lines and comments
+// result in no rule. Patterns without a slash match on all levels below the base directory.
+func parseIgnoreRule(base string, line string) (ignoreRule, bool) {
+	pattern := strings.TrimRight(line, " ")
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: base}
+	if strings.HasPrefix(pattern, "!") {
+		rule.negate = true
+		pattern = strings.TrimPrefix(pattern, "!")
+	}
+	pattern = strings.TrimPrefix(pattern, "\\")
+	if strings.HasSuffix(pattern, "/") {
+		rule.dirOnly = true
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+
+	if strings.Contains(pattern, "/") {
+		rule.glob = strings.TrimPrefix(pattern, "/")
+	} else {
+		rule.glob = "**/" + pattern
+	}
+	return rule, doublestar.ValidatePattern(rule.glob)
+}
+
+// isIgnored returns true if the path is ignored by any ignore file. A rule matches a path if it
+// matches the path itself or any of its parent directories. If multiple rules match, the last one
+// wins. Rules from deeper directories are loaded later and therefore take precedence.
+func (ignores *ignoreFiles) isIgnored(path string, isDir bool) bool {
+	if len(ignores.rules) == 0 {
+		return false
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+
+	ignored := false
+	for _, rule := range ignores.rules {
+		if rule.matches(path, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// matches returns true if the rule matches the path or any parent directory of the path below
+// the base directory of the rule.
+func (rule ignoreRule) matches(path string, isDir bool) bool {
+	rel, err := filepath.Rel(rule.base, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	candidates := pathWithParents(filepath.ToSlash(rel))
+	for i, candidate := range candidates {
+		candidateIsDir := i < len(candidates)-1 || isDir
+		if rule.dirOnly && !candidateIsDir {
+			continue
+		}
+		if doublestar.MatchUnvalidated(rule.glob, candidate) {
+			return true
+		}
+	}
+	return false
+}
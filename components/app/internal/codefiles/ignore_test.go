@@ -0,0 +1,179 @@
+package codefiles
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createFiles(t *testing.T, dir string, files map[string]string) {
+	for path, content := range files {
+		fullPath := filepath.Join(dir, path)
+		err := os.MkdirAll(filepath.Dir(fullPath), 0755)
+		assert.Nil(t, err, "Error creating directory")
+
+		err = os.WriteFile(fullPath, []byte(content), 0644)
+		assert.Nil(t, err, "Error creating file")
+	}
+}
+
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	assert.Nil(t, err, "Error getting working directory")
+	err = os.Chdir(dir)
+	assert.Nil(t, err, "Error changing working directory")
+	t.Cleanup(func() {
+		err := os.Chdir(wd)
+		assert.Nil(t, err, "Error restoring working directory")
+	})
+}
+
+func Test_ShouldParseIgnoreRules(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		line     string
+		expected ignoreRule
+		ok       bool
+	}{
+		{line: "target/", expected: ignoreRule{base: "/src", glob: "**/target", dirOnly: true}, ok: true},
+		{line: "*.bak.sh", expected: ignoreRule{base: "/src", glob: "**/*.bak.sh"}, ok: true},
+		{line: "/build.sh", expected: ignoreRule{base: "/src", glob: "build.sh"}, ok: true},
+		{line: "lib/*.sh", expected: ignoreRule{base: "/src", glob: "lib/*.sh"}, ok: true},
+		{line: "!keep.sh", expected: ignoreRule{base: "/src", glob: "**/keep.sh", negate: true}, ok: true},
+		{line: "\\#file.sh", expected: ignoreRule{base: "/src", glob: "**/#file.sh"}, ok: true},
+		{line: "# comment", ok: false},
+		{line: "", ok: false},
+	}
+
+	for _, test := range tests {
+		rule, ok := parseIgnoreRule("/src", test.line)
+		assert.Equal(test.ok, ok, "Incorrect status for: "+test.line)
+		if test.ok {
+			assert.Equal(test.expected, rule, "Incorrect rule for: "+test.line)
+		}
+	}
+}
+
+func Test_ShouldMatchIgnoreRules(t *testing.T) {
+	assert := assert.New(t)
+
+	ignores := newIgnoreFiles(true)
+	for _, line := range []string{"target/", "*.bak.sh", "!keep.bak.sh", "/root-only.sh"} {
+		rule, _ := parseIgnoreRule("/src", line)
+		ignores.rules = append(ignores.rules, rule)
+	}
+	rule, _ := parseIgnoreRule("/src/lib", "local.sh")
+	ignores.rules = append(ignores.rules, rule)
+
+	tests := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{path: "/src/target", isDir: true, ignored: true},
+		{path: "/src/app/target/script.sh", ignored: true},
+		{path: "/src/target", isDir: false, ignored: false},
+		{path: "/src/lib/old.bak.sh", ignored: true},
+		{path: "/src/lib/keep.bak.sh", ignored: false},
+		{path: "/src/root-only.sh", ignored: true},
+		{path: "/src/lib/root-only.sh", ignored: false},
+		{path: "/src/lib/local.sh", ignored: true},
+		{path: "/src/local.sh", ignored: false},
+		{path: "/other/old.bak.sh", ignored: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.ignored, ignores.isIgnored(test.path, test.isDir), "Incorrect ignore status for: "+test.path)
+	}
+}
+
+// Test_ShouldFindSourceCodeFilesWithoutIgnoredFiles tests the case where the source directory
+// contains ignore files. The .source2adocignore files are always respected, the .gitignore files
+// only if enabled.
+func Test_ShouldFindSourceCodeFilesWithoutIgnoredFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	srcDir := t.TempDir()
+	createFiles(t, srcDir, map[string]string{
+		GitignoreFilename:                 "node_modules/\n",
+		IgnoreFilename:                    "*.bak.sh\n",
+		"app/" + GitignoreFilename:        "target/\n",
+		"app/" + IgnoreFilename:           "!keep.bak.sh\n",
+		"script.sh":                       "",
+		"old.bak.sh":                      "",
+		"node_modules/lib/script.sh":      "",
+		"app/build.sh":                    "",
+		"app/keep.bak.sh":                 "",
+		"app/target/generated.sh":         "",
+		"app/node_modules/lib/install.sh": "",
+	})
+
+	tests := []struct {
+		gitignore bool
+		expected  []string
+	}{
+		{
+			gitignore: false,
+			expected: []string{
+				"script.sh", "node_modules/lib/script.sh", "app/build.sh", "app/keep.bak.sh",
+				"app/target/generated.sh", "app/node_modules/lib/install.sh",
+			},
+		},
+		{
+			gitignore: true,
+			expected:  []string{"script.sh", "app/build.sh", "app/keep.bak.sh"},
+		},
+	}
+
+	for _, test := range tests {
+		finder := NewFinder(srcDir)
+		finder.SetGitignore(test.gitignore)
+
+		files, err := finder.FindSourceCodeFiles()
+		assert.NoError(err, "Should not return an error")
+		assert.Equal(len(test.expected), len(files), "Should return the expected number of files")
+
+		for _, expected := range test.expected {
+			assert.Contains(files, NewCodeFile(filepath.Join(srcDir, expected)), "Expected file not found")
+		}
+	}
+}
+
+// Test_ShouldRespectGitignoreFilesAboveSourceDir tests the case where the source directory is
+// located inside a git repository. The expected result is that the .gitignore files of all
+// directories up to the root of the repository are respected (with patterns relative to their
+// own directory), no matter if the source directory is absolute or relative.
+func Test_ShouldRespectGitignoreFilesAboveSourceDir(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		GitignoreFilename:                       "*.sh\n",
+		"repo/.git/HEAD":                        "",
+		"repo/" + GitignoreFilename:             "/src/vendor/\n*.bak.sh\n",
+		"repo/components/" + GitignoreFilename:  "generated/\n",
+		"repo/components/src/script.sh":         "",
+		"repo/components/src/old.bak.sh":        "",
+		"repo/components/src/generated/code.sh": "",
+		"repo/components/src/vendor/lib.sh":     "",
+		"repo/src/vendor/lib.sh":                "",
+	})
+
+	chdir(t, filepath.Join(dir, "repo"))
+
+	for _, srcDir := range []string{filepath.Join(dir, "repo/components/src"), "components/src"} {
+		finder := NewFinder(srcDir)
+		finder.SetGitignore(true)
+
+		files, err := finder.FindSourceCodeFiles()
+		assert.Nil(err, "Error finding code files")
+		expected := []*CodeFile{
+			NewCodeFile(filepath.Join(srcDir, "script.sh")),
+			NewCodeFile(filepath.Join(srcDir, "vendor/lib.sh")),
+		}
+		assert.Equal(expected, files, "Incorrect code files for "+srcDir)
+	}
+}
@@ -0,0 +1,213 @@
+package codefiles
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultCommentMarker marks the relevant comments for the documentation.
+const DefaultCommentMarker = "##"
+
+// BlockParser extracts language specific DocumentationParts (e.g. the docs of Bash functions)
+// from a CodeFile. The content of the CodeFile is already read when the parser is invoked.
+type BlockParser func(cf *CodeFile) []DocumentationPart
+
+// FilenameMatcher decides if a file belongs to a language based on the filename.
+type FilenameMatcher func(filename string) bool
+
+// MatchSuffix returns a FilenameMatcher for filenames ending with the given suffix.
+func MatchSuffix(suffix string) FilenameMatcher {
+	return func(filename string) bool {
+		return strings.HasSuffix(filename, suffix)
+	}
+}
+
+// MatchPrefix returns a FilenameMatcher for filenames starting with the given prefix.
+func MatchPrefix(prefix string) FilenameMatcher {
+	return func(filename string) bool {
+		return strings.HasPrefix(filename, prefix)
+	}
+}
+
+// Language represents a supported source code language. Each language decides which files it
+// is responsible for and contributes its own parsers for language specific documentation blocks.
+type Language interface {
+	// Name returns the unique name of the language.
+	Name() string
+
+	// Matches returns true if the file with the given filename is written in this language.
+	Matches(filename string) bool
+
+	// MatchesInterpreter returns true if scripts executed by the given interpreter (taken from
+	// the shebang, e.g. `bash`) are written in this language.
+	MatchesInterpreter(interpreter string) bool
+
+	// CommentMarker returns the marker of relevant comments (e.g. `##`).
+	CommentMarker() string
+
+	// Parsers returns the parsers for language specific documentation blocks. The header docs
+	// and the metadata are parsed for all languages and are not part of this list.
+	Parsers() []BlockParser
+}
+
+// language is the default implementation of the Language interface.
+type language struct {
+	name         string
+	marker       string
+	matchers     []FilenameMatcher
+	interpreters []string
+	parsers      []BlockParser
+}
+
+// NewLanguage acts as a constructor for a new Language instance. Relevant comments are marked
+// with the given marker (e.g. the DefaultCommentMarker).
+func NewLanguage(name string, marker string, matchers []FilenameMatcher, parsers ...BlockParser) Language {
+	return NewScriptLanguage(name, marker, matchers, []string{}, parsers...)
+}
+
+// NewScriptLanguage acts as a constructor for a new Language instance whose files can be
+// executed directly. Besides the filename, these files are identified by the interpreter from
+// their shebang (e.g. `bash` for `#!/usr/bin/env bash`). Relevant comments are marked with the
+// given marker.
+func NewScriptLanguage(name string, marker string, matchers []FilenameMatcher, interpreters []string, parsers ...BlockParser) Language {
+	return &language{
+		name:         name,
+		marker:       marker,
+		matchers:     matchers,
+		interpreters: interpreters,
+		parsers:      parsers,
+	}
+}
+
+// Name returns the unique name of the language.
+func (lang *language) Name() string {
+	return lang.name
+}
+
+// Matches returns true if any of the FilenameMatchers of the language matches the filename.
+func (lang *language) Matches(filename string) bool {
+	for _, matcher := range lang.matchers {
+		if matcher(filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesInterpreter returns true if the interpreter is one of the interpreters of the language.
+// Only a dotted version suffix is ignored, so `python3.12` matches `python3` but neither `python`
+// nor `python2` match `python3` (and vice versa).
+func (lang *language) MatchesInterpreter(interpreter string) bool {
+	base, _, _ := strings.Cut(interpreter, ".")
+	candidates := []string{interpreter, base}
+	for _, known := range lang.interpreters {
+		for _, candidate := range candidates {
+			if candidate == known {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CommentMarker returns the marker of relevant comments.
+func (lang *language) CommentMarker() string {
+	return lang.marker
+}
+
+// Parsers returns the parsers for language specific documentation blocks.
+func (lang *language) Parsers() []BlockParser {
+	return lang.parsers
+}
+
+// LanguageRegistry holds all supported languages in the order of their registration. The order
+// is relevant because the first language matching a filename wins.
+type LanguageRegistry struct {
+	languages []Language
+}
+
+// NewLanguageRegistry acts as a constructor for a new and empty LanguageRegistry instance.
+func NewLanguageRegistry() *LanguageRegistry {
+	return &LanguageRegistry{
+		languages: []Language{},
+	}
+}
+
+// Register adds a language to the registry. Each language name can only be registered once.
+func (registry *LanguageRegistry) Register(lang Language) error {
+	if _, ok := registry.ByName(lang.Name()); ok {
+		return fmt.Errorf("language already registered: %s", lang.Name())
+	}
+	registry.languages = append(registry.languages, lang)
+	return nil
+}
+
+// Languages returns all registered languages in the order of their registration.
+func (registry *LanguageRegistry) Languages() []Language {
+	return registry.languages
+}
+
+// Names returns the names of all registered languages in the order of their registration.
+func (registry *LanguageRegistry) Names() []string {
+	names := []string{}
+	for _, lang := range registry.languages {
+		names = append(names, lang.Name())
+	}
+	return names
+}
+
+// ByName returns the language with the given name.
+func (registry *LanguageRegistry) ByName(name string) (Language, bool) {
+	for _, lang := range registry.languages {
+		if lang.Name() == name {
+			return lang, true
+		}
+	}
+	return nil, false
+}
+
+// ByFilename returns the first registered language matching the given filename.
+func (registry *LanguageRegistry) ByFilename(filename string) (Language, bool) {
+	for _, lang := range registry.languages {
+		if lang.Matches(filename) {
+			return lang, true
+		}
+	}
+	return nil, false
+}
+
+// ByInterpreter returns the first registered language matching the given interpreter.
+func (registry *LanguageRegistry) ByInterpreter(interpreter string) (Language, bool) {
+	for _, lang := range registry.languages {
+		if lang.MatchesInterpreter(interpreter) {
+			return lang, true
+		}
+	}
+	return nil, false
+}
+
+// SupportedLanguages is the registry of all languages supported by the app. The languages which
+// are built into the app are registered by the init function of this package.
+var SupportedLanguages = NewLanguageRegistry()
+
+// RegisterLanguage adds a language to the SupportedLanguages.
+func RegisterLanguage(lang Language) error {
+	return SupportedLanguages.Register(lang)
+}
+
+func init() {
+	languages := []Language{
+		NewScriptLanguage(LanguageBash, DefaultCommentMarker, []FilenameMatcher{MatchSuffix(".sh")}, []string{"sh", "bash", "dash", "ksh", "zsh"}, (*CodeFile).parseFunctionDocs),
+		NewLanguage(LanguageYml, DefaultCommentMarker, []FilenameMatcher{MatchSuffix(".yml"), MatchSuffix(".yaml")}, (*CodeFile).parseYamlKeys),
+		NewLanguage(LanguageDockerfile, DefaultCommentMarker, []FilenameMatcher{MatchPrefix("Dockerfile"), MatchSuffix(".Dockerfile")}, (*CodeFile).parseDockerInstructions),
+		NewLanguage(LanguageVagrant, DefaultCommentMarker, []FilenameMatcher{MatchPrefix("Vagrantfile"), MatchSuffix(".Vagrantfile")}),
+		NewScriptLanguage(LanguageMake, DefaultCommentMarker, []FilenameMatcher{MatchPrefix("Makefile"), MatchSuffix(".Makefile")}, []string{"make"}, (*CodeFile).parseMakeTargets),
+	}
+
+	for _, lang := range languages {
+		err := RegisterLanguage(lang)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
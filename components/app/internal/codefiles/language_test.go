@@ -0,0 +1,89 @@
+package codefiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldRegisterLanguagesInOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewLanguageRegistry()
+	err := registry.Register(NewLanguage("first", DefaultCommentMarker, []FilenameMatcher{MatchSuffix(".txt")}))
+	assert.Nil(err, "Error registering language")
+	err = registry.Register(NewLanguage("second", DefaultCommentMarker, []FilenameMatcher{MatchPrefix("notes")}))
+	assert.Nil(err, "Error registering language")
+
+	assert.Equal([]string{"first", "second"}, registry.Names(), "Incorrect order of languages")
+
+	lang, ok := registry.ByFilename("notes.txt")
+	assert.True(ok, "Language should be found")
+	assert.Equal("first", lang.Name(), "First registered language should win")
+
+	lang, ok = registry.ByFilename("notes.md")
+	assert.True(ok, "Language should be found")
+	assert.Equal("second", lang.Name(), "Incorrect language")
+
+	_, ok = registry.ByFilename("readme.md")
+	assert.False(ok, "Language should not be found")
+}
+
+func Test_ShouldUseCommentMarkerOfLanguage(t *testing.T) {
+	lang := NewLanguage("ini", ";;", []FilenameMatcher{MatchSuffix(".ini")})
+	assert.Equal(t, ";;", lang.CommentMarker(), "Incorrect comment marker")
+}
+
+func Test_ShouldNotRegisterLanguageTwice(t *testing.T) {
+	registry := NewLanguageRegistry()
+	err := registry.Register(NewLanguage("twice", DefaultCommentMarker, []FilenameMatcher{}))
+	assert.Nil(t, err, "Error registering language")
+
+	err = registry.Register(NewLanguage("twice", DefaultCommentMarker, []FilenameMatcher{}))
+	assert.NotNil(t, err, "Registering a language twice should fail")
+}
+
+func Test_ShouldContainBuiltInLanguages(t *testing.T) {
+	assert := assert.New(t)
+
+	expected := []string{LanguageBash, LanguageYml, LanguageDockerfile, LanguageVagrant, LanguageMake}
+	assert.Equal(expected, SupportedLanguages.Names(), "Incorrect built-in languages")
+
+	lang, ok := SupportedLanguages.ByName(LanguageBash)
+	assert.True(ok, "Language should be found")
+	assert.Equal(DefaultCommentMarker, lang.CommentMarker(), "Incorrect comment marker")
+	assert.Len(lang.Parsers(), 1, "Incorrect number of parsers")
+}
+
+func Test_ShouldFindLanguageByInterpreter(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewLanguageRegistry()
+	err := registry.Register(NewScriptLanguage("python", DefaultCommentMarker, []FilenameMatcher{MatchSuffix(".py")}, []string{"python3"}))
+	assert.Nil(err, "Error registering language")
+	err = registry.Register(NewLanguage("text", DefaultCommentMarker, []FilenameMatcher{MatchSuffix(".txt")}))
+	assert.Nil(err, "Error registering language")
+
+	tests := []struct {
+		interpreter string
+		found       bool
+	}{
+		{interpreter: "python", found: false},
+		{interpreter: "python3", found: true},
+		{interpreter: "python3.12", found: true},
+		{interpreter: "python2", found: false},
+		{interpreter: "python2.7", found: false},
+		{interpreter: "python33", found: false},
+		{interpreter: "bash", found: false},
+		{interpreter: "text", found: false},
+		{interpreter: "", found: false},
+	}
+
+	for _, test := range tests {
+		lang, ok := registry.ByInterpreter(test.interpreter)
+		assert.Equal(test.found, ok, "Incorrect result for "+test.interpreter)
+		if ok {
+			assert.Equal("python", lang.Name(), "Incorrect language")
+		}
+	}
+}
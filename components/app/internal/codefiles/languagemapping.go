@@ -0,0 +1,59 @@
+package codefiles
+
+import (
+	"fmt"
+	"strings"
+)
+
+// languageMapping assigns a supported language to all files matching a glob pattern. Mappings
+// are used for files which are not recognized by their filename (e.g. extensionless scripts).
+type languageMapping struct {
+	matcher *pathMatcher
+	lang    string
+}
+
+// languageMappings holds all language mappings of a source directory.
+type languageMappings []languageMapping
+
+// newLanguageMappings parses mappings like `bin/deploy=sh` for the given source directory. The
+// pattern follows the same rules as the exclude patterns (see pathMatcher). The language must be
+// one of the SupportedLanguages.
+func newLanguageMappings(srcDir string, mappings []string) (languageMappings, error) {
+	result := languageMappings{}
+	for _, mapping := range mappings {
+		pattern, lang, ok := cutLast(mapping, "=")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("invalid language mapping (expected pattern=language): %s", mapping)
+		}
+		if _, supported := SupportedLanguages.ByName(lang); !supported {
+			return nil, fmt.Errorf("unsupported language in mapping: %s", mapping)
+		}
+
+		matcher, err := newPathMatcher(srcDir, []string{pattern})
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, languageMapping{matcher: matcher, lang: lang})
+	}
+	return result, nil
+}
+
+// cutLast slices s around the last instance of sep.
+func cutLast(s string, sep string) (before string, after string, found bool) {
+	i := strings.LastIndex(s, sep)
+	if i < 0 {
+		return s, "", false
+	}
+	return s[:i], s[i+len(sep):], true
+}
+
+// languageOf returns the language mapped to the given path. If multiple mappings match, the last
+// one wins. If no mapping matches, ok is false.
+func (mappings languageMappings) languageOf(path string) (lang string, ok bool) {
+	for _, mapping := range mappings {
+		if mapping.matcher.matches(path) {
+			lang, ok = mapping.lang, true
+		}
+	}
+	return lang, ok
+}
@@ -0,0 +1,79 @@
+package codefiles
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldMapLanguages(t *testing.T) {
+	assert := assert.New(t)
+
+	mappings, err := newLanguageMappings("/src", []string{"bin/*=sh", "bin/*.yml=yml", "/src/tools/build=Makefile"})
+	assert.NoError(err, "Should not return an error")
+
+	tests := []struct {
+		path     string
+		expected string
+		ok       bool
+	}{
+		{path: "/src/bin/deploy", expected: LanguageBash, ok: true},
+		{path: "/src/bin/config.yml", expected: LanguageYml, ok: true},
+		{path: "/src/tools/build", expected: LanguageMake, ok: true},
+		{path: "/src/tools/deploy", expected: "", ok: false},
+		{path: "/src/scripts/bin/deploy", expected: "", ok: false},
+	}
+
+	for _, test := range tests {
+		lang, ok := mappings.languageOf(test.path)
+		assert.Equal(test.ok, ok, "Incorrect match status for "+test.path)
+		assert.Equal(test.expected, lang, "Incorrect language for "+test.path)
+	}
+}
+
+func Test_ShouldFailForInvalidLanguageMappings(t *testing.T) {
+	assert := assert.New(t)
+
+	mappings := []string{"bin/deploy", "=sh", "bin/deploy=go", "bin/[deploy=sh"}
+	for _, mapping := range mappings {
+		_, err := newLanguageMappings("/src", []string{mapping})
+		assert.Error(err, "Should return an error for "+mapping)
+	}
+}
+
+func Test_ShouldFindSourceCodeFilesWithIncludesAndLanguageMappings(t *testing.T) {
+	assert := assert.New(t)
+
+	srcDir := t.TempDir()
+	createFiles(t, srcDir, map[string]string{
+		"bin/deploy":         "",
+		"bin/release":        "",
+		"bin/README":         "",
+		"src/script.sh":      "",
+		"src/Makefile":       "",
+		"tools/build-image":  "",
+		"tools/some.yml":     "",
+		"tools/unmapped.txt": "",
+	})
+
+	finder := NewFinder(srcDir)
+	finder.SetIncludes([]string{"bin", "tools", "src/*.sh"})
+	finder.SetExcludes([]string{"bin/release"})
+	finder.SetLanguageMappings([]string{"bin/*=sh", "tools/build-image=Dockerfile"})
+
+	files, err := finder.FindSourceCodeFiles()
+	assert.NoError(err, "Should not return an error")
+
+	expected := []*CodeFile{
+		NewCodeFileWithLanguage(filepath.Join(srcDir, "bin/deploy"), LanguageBash),
+		NewCodeFileWithLanguage(filepath.Join(srcDir, "bin/README"), LanguageBash),
+		NewCodeFileWithLanguage(filepath.Join(srcDir, "tools/build-image"), LanguageDockerfile),
+		NewCodeFile(filepath.Join(srcDir, "tools/some.yml")),
+		NewCodeFile(filepath.Join(srcDir, "src/script.sh")),
+	}
+	assert.Equal(len(expected), len(files), "Should return the expected number of files")
+	for _, file := range expected {
+		assert.Contains(files, file, "Expected file not found")
+	}
+}
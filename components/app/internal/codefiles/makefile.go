@@ -0,0 +1,114 @@
+package codefiles
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+)
+
+// makeTargetPattern matches Makefile rules like `build: deps` and `build: deps ## Build the app`.
+// Variable assignments like `VAR := value` are no rules and are ruled out by the pattern.
+var makeTargetPattern = regexp.MustCompile(`^([\w./%-]+(?:[ \t]+[\w./%-]+)*)[ \t]*:([^=:].*|)$`)
+
+// makeVariablePattern matches target-specific variable assignments like `build: CFLAGS = -O2` (the
+// part after the colon). These lines set variables for the target and are no rules.
+var makeVariablePattern = regexp.MustCompile(`^[ \t]*(?:(?:export|override|private)[ \t]+)*[\w.-]+[ \t]*(?::{1,3}|[+?!])?=`)
+
+// makeTarget represents a single target of a Makefile.
+type makeTarget struct {
+	name          string
+	prerequisites string
+	description   string
+}
+
+// parseMakeTarget returns the target defined in the given line. Trailing comments (marked with
+// the given comment marker) are used as description. If the line is no rule or the target is a
+// special target (e.g. `.PHONY`) or the line assigns a target-specific variable, nil is returned.
+func parseMakeTarget(line string, marker string) *makeTarget {
+	matches := makeTargetPattern.FindStringSubmatch(line)
+	if matches == nil || strings.HasPrefix(matches[1], ".") {
+		return nil
+	}
+
+	prerequisites, description, _ := strings.Cut(matches[2], marker)
+	if makeVariablePattern.MatchString(prerequisites) {
+		return nil
+	}
+	return &makeTarget{
+		name:          matches[1],
+		prerequisites: strings.TrimSpace(prerequisites),
+		description:   strings.TrimSpace(description),
+	}
+}
+
+// makeTargets finds all targets of a Makefile. The description of a target is taken from the
+// comments (marked with `##`) immediately preceding the target or from a trailing `##` comment.
+// Targets which are defined multiple times are merged into the first definition.
+func (cf *CodeFile) makeTargets() []*makeTarget {
+	targets := []*makeTarget{}
+	byName := map[string]*makeTarget{}
+	targetDocs := []string{}
+	lines := strings.Split(cf.fileContent, "\n")
+	for _, line := range lines {
+		if text, ok := cf.docLine(line); ok {
+			targetDocs = append(targetDocs, text)
+			continue
+		}
+
+		target := parseMakeTarget(line, cf.commentMarker())
+		if target != nil {
+			if target.description == "" {
+				target.description = strings.Join(targetDocs, " ")
+			}
+			if known, ok := byName[target.name]; ok {
+				known.merge(target)
+			} else {
+				byName[target.name] = target
+				targets = append(targets, target)
+			}
+		}
+		targetDocs = []string{}
+	}
+	return targets
+}
+
+// merge adds the prerequisites of another definition of the same target. The description of the
+// first definition wins, unless it has no description.
+func (target *makeTarget) merge(other *makeTarget) {
+	if other.prerequisites != "" {
+		target.prerequisites = strings.TrimSpace(target.prerequisites + " " + other.prerequisites)
+	}
+	if target.description == "" {
+		target.description = other.description
+	}
+}
+
+// parseMakeTargets finds all targets of a Makefile and returns them as a table.
+//
+// See "Rules for the Makefile target documentation" in `docs/modules/ROOT/pages/index.adoc`.
+func (cf *CodeFile) parseMakeTargets() []DocumentationPart {
+	targets := cf.makeTargets()
+	if len(targets) == 0 {
+		return nil
+	}
+	return []DocumentationPart{NewDocumentationPart(DocumentationPartTargets, renderMakeTargets(cf.docsRenderer(), targets))}
+}
+
+// renderMakeTargets renders the targets of a Makefile as a table.
+func renderMakeTargets(renderer render.Renderer, targets []*makeTarget) string {
+	rows := [][]string{}
+	for _, target := range targets {
+		rows = append(rows, []string{target.name, target.prerequisites, target.description})
+	}
+
+	content := "\n"
+	content += renderer.Heading(1, "Targets")
+	content += "\n"
+	content += renderer.Table(render.Table{
+		Cols:   []int{1, 2, 5},
+		Header: []string{"Target", "Prerequisites", "Description"},
+		Rows:   rows,
+	})
+	return content
+}
@@ -0,0 +1,108 @@
+package codefiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldParseMakeTarget(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		line     string
+		expected *makeTarget
+	}{
+		{line: "all:", expected: &makeTarget{name: "all"}},
+		{line: "build: deps lint", expected: &makeTarget{name: "build", prerequisites: "deps lint"}},
+		{line: "test: build ## Run the tests", expected: &makeTarget{name: "test", prerequisites: "build", description: "Run the tests"}},
+		{line: "clean: ## Remove build artifacts", expected: &makeTarget{name: "clean", description: "Remove build artifacts"}},
+		{line: "target/app: main.go", expected: &makeTarget{name: "target/app", prerequisites: "main.go"}},
+		{line: ".PHONY: all build", expected: nil},
+		{line: "VERSION := 1.0.0", expected: nil},
+		{line: "VERSION = 1.0.0", expected: nil},
+		{line: "build: CFLAGS = -O2", expected: nil},
+		{line: "build: CFLAGS := -O2 ## Optimize", expected: nil},
+		{line: "build: CFLAGS += -O2", expected: nil},
+		{line: "build: CFLAGS ?= -O2", expected: nil},
+		{line: "build: export CFLAGS = -O2", expected: nil},
+		{line: "build: deps ## Set CFLAGS = -O2", expected: &makeTarget{name: "build", prerequisites: "deps", description: "Set CFLAGS = -O2"}},
+		{line: "\t@echo \"Hello, World!\"", expected: nil},
+	}
+
+	for _, test := range tests {
+		target := parseMakeTarget(test.line, DefaultCommentMarker)
+		assert.Equal(test.expected, target, "Incorrect target for: "+test.line)
+	}
+}
+
+func Test_ShouldParseMakeTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		name: "Makefile",
+		lang: LanguageMake,
+		fileContent: `## Header docs
+
+.PHONY: all build test
+
+## Build the app.
+## Multiple lines are joined.
+build: deps
+	go build .
+
+test: build ## Run the tests
+	go test ./...
+
+deps:
+	go mod download
+`,
+		documentationParts: []DocumentationPart{},
+	}
+
+	expected := `
+== Targets
+
+[cols="1,2,5"]
+|===
+|Target |Prerequisites |Description
+
+|build |deps |Build the app. Multiple lines are joined.
+|test |build |Run the tests
+|deps | |
+|===
+`
+
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	part := codeFile.documentationParts[len(codeFile.documentationParts)-1]
+	assert.Equal(DocumentationPartTargets, part.SectionType(), "Incorrect section type")
+	assert.Equal(expected, part.SectionContent(), "Incorrect targets table")
+}
+
+func Test_ShouldMergeDuplicateMakeTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		name: "Makefile",
+		lang: LanguageMake,
+		fileContent: `build: CFLAGS = -O2
+build: deps
+
+test: build ## Run the tests
+
+## Build the app
+build: lint
+	go build .
+
+test: ## Run the tests again
+`,
+	}
+
+	expected := []*makeTarget{
+		{name: "build", prerequisites: "deps lint", description: "Build the app"},
+		{name: "test", prerequisites: "build", description: "Run the tests"},
+	}
+	assert.Equal(expected, codeFile.makeTargets(), "Duplicate targets should be merged")
+}
@@ -0,0 +1,72 @@
+package codefiles
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// shebangPeekSize is the maximum number of bytes read from a file to detect the shebang. The
+// shebang is the first line of a file, so there is no need to read the whole file.
+const shebangPeekSize = 256
+
+// readShebang returns the first line of the file if it is a shebang (e.g. `#!/bin/bash`). If the
+// file has no shebang, an empty string is returned.
+func readShebang(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read shebang: %v", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, shebangPeekSize)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read shebang: %v", err)
+	}
+
+	line, _, _ := bytes.Cut(head[:n], []byte("\n"))
+	if !bytes.HasPrefix(line, []byte("#!")) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(line)), nil
+}
+
+// shebangInterpreter returns the name of the interpreter from a shebang line. Both absolute paths
+// (e.g. `#!/bin/bash`) and the env command (e.g. `#!/usr/bin/env -S python3 -u`) are supported.
+func shebangInterpreter(shebang string) string {
+	fields := strings.Fields(strings.TrimPrefix(shebang, "#!"))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	interpreter := filepath.Base(fields[0])
+	if interpreter != "env" {
+		return interpreter
+	}
+	for _, field := range fields[1:] {
+		// Skip the options and the variable assignments of the env command
+		if !strings.HasPrefix(field, "-") && !strings.Contains(field, "=") {
+			return filepath.Base(field)
+		}
+	}
+	return ""
+}
+
+// identifyLanguageByShebang identifies the language of the file based on the interpreter from the
+// shebang. Return the language and a boolean indicating if the language is supported.
+func identifyLanguageByShebang(path string) (string, bool, error) {
+	shebang, err := readShebang(path)
+	if err != nil || shebang == "" {
+		return LanguageNotSupported, false, err
+	}
+
+	lang, ok := SupportedLanguages.ByInterpreter(shebangInterpreter(shebang))
+	if !ok {
+		return LanguageNotSupported, false, nil
+	}
+	return lang.Name(), true, nil
+}
@@ -0,0 +1,95 @@
+package codefiles
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldReadShebang(t *testing.T) {
+	assert := assert.New(t)
+
+	srcDir := t.TempDir()
+	createFiles(t, srcDir, map[string]string{
+		"deploy":  "#!/bin/bash\n## Deploy the app\necho deploy\n",
+		"empty":   "",
+		"no-line": "#!/bin/sh",
+		"notes":   "# Notes\n#!/bin/bash\n",
+		"long":    strings.Repeat("x", 2*shebangPeekSize) + "\n",
+	})
+
+	tests := []struct {
+		filename string
+		expected string
+	}{
+		{filename: "deploy", expected: "#!/bin/bash"},
+		{filename: "empty", expected: ""},
+		{filename: "no-line", expected: "#!/bin/sh"},
+		{filename: "notes", expected: ""},
+		{filename: "long", expected: ""},
+	}
+
+	for _, test := range tests {
+		shebang, err := readShebang(filepath.Join(srcDir, test.filename))
+		assert.NoError(err, "Should not return an error")
+		assert.Equal(test.expected, shebang, "Incorrect shebang for "+test.filename)
+	}
+
+	_, err := readShebang(filepath.Join(srcDir, "missing"))
+	assert.Error(err, "Should return an error for missing files")
+}
+
+func Test_ShouldFindShebangInterpreter(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		shebang  string
+		expected string
+	}{
+		{shebang: "#!/bin/bash", expected: "bash"},
+		{shebang: "#! /bin/sh -e", expected: "sh"},
+		{shebang: "#!/usr/bin/env sh", expected: "sh"},
+		{shebang: "#!/usr/bin/env python3", expected: "python3"},
+		{shebang: "#!/usr/bin/env -S make -f", expected: "make"},
+		{shebang: "#!/usr/bin/env LANG=C bash", expected: "bash"},
+		{shebang: "#!/usr/bin/env", expected: ""},
+		{shebang: "#!", expected: ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, shebangInterpreter(test.shebang), "Incorrect interpreter for "+test.shebang)
+	}
+}
+
+func Test_ShouldFindSourceCodeFilesByShebang(t *testing.T) {
+	assert := assert.New(t)
+
+	srcDir := t.TempDir()
+	createFiles(t, srcDir, map[string]string{
+		"bin/deploy":  "#!/usr/bin/env bash\n",
+		"bin/release": "#!/bin/sh\n",
+		"bin/build":   "#!/usr/bin/make -f\n",
+		"bin/migrate": "#!/usr/bin/env python3\n",
+		"bin/notes":   "deploy and release\n",
+		"bin/mapped":  "#!/bin/bash\n",
+	})
+
+	finder := NewFinder(srcDir)
+	finder.SetLanguageMappings([]string{"bin/mapped=Makefile"})
+
+	files, err := finder.FindSourceCodeFiles()
+	assert.NoError(err, "Should not return an error")
+
+	expected := []*CodeFile{
+		NewCodeFileWithLanguage(filepath.Join(srcDir, "bin/deploy"), LanguageBash),
+		NewCodeFileWithLanguage(filepath.Join(srcDir, "bin/release"), LanguageBash),
+		NewCodeFileWithLanguage(filepath.Join(srcDir, "bin/build"), LanguageMake),
+		NewCodeFileWithLanguage(filepath.Join(srcDir, "bin/mapped"), LanguageMake),
+	}
+	assert.Equal(len(expected), len(files), "Should return the expected number of files")
+	for _, file := range expected {
+		assert.Contains(files, file, "Expected file not found")
+	}
+}
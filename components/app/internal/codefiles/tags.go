@@ -0,0 +1,183 @@
+package codefiles
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+)
+
+// JavaDoc-style tags which are translated into the output format (e.g. AsciiDoc). Tags are only
+// recognized at the beginning of a documentation line.
+const (
+	tagArg    = "@arg"
+	tagAuthor = "@author"
+	tagSince  = "@since"
+	tagSee    = "@see"
+	tagLink   = "@link"
+)
+
+// taggedDocs represents a documentation block (e.g. the header docs or the docs of a function)
+// with all JavaDoc-style tags separated from the actual text.
+type taggedDocs struct {
+	text      []string
+	arguments [][]string
+	metadata  [][]string
+	see       []seeTag
+}
+
+// seeTag represents a `@see` tag with the target as written in the code file and the cross
+// reference to the documentation page of the target. The target is followed by an optional label
+// (e.g. `@see lib/util.sh for details`), which is used as text of the cross reference.
+type seeTag struct {
+	target string
+	xref   string
+}
+
+// parseTags splits the lines of a documentation block into text and tags. `@link` tags are
+// translated in place, all other tags are collected to be rendered as tables or lists. Blank
+// lines which are only separated by the collected tags are collapsed (see addText).
+func (cf *CodeFile) parseTags(lines []string) *taggedDocs {
+	docs := &taggedDocs{}
+	afterTags := false
+	for _, line := range lines {
+		tag, value := splitTag(line)
+		switch tag {
+		case tagArg:
+			docs.arguments = append(docs.arguments, splitArgument(value))
+		case tagAuthor:
+			docs.metadata = append(docs.metadata, []string{"Author", value})
+		case tagSince:
+			docs.metadata = append(docs.metadata, []string{"Since", value})
+		case tagSee:
+			target, label := splitSee(value)
+			docs.see = append(docs.see, seeTag{target: target, xref: cf.xref(target, label)})
+		case tagLink:
+			afterTags = !docs.addText(cf.link(value), afterTags)
+			continue
+		default:
+			afterTags = !docs.addText(line, afterTags)
+			continue
+		}
+		afterTags = true
+	}
+	if afterTags && len(docs.text) > 0 && isBlank(docs.text[len(docs.text)-1]) {
+		docs.text = docs.text[:len(docs.text)-1]
+	}
+	return docs
+}
+
+// addText adds a line to the text of the documentation block and returns true if it was added.
+// A blank line directly after collected tags is skipped if the text is empty or already ends with
+// a blank line, so removing the tag lines does not leave doubled blank lines behind.
+func (docs *taggedDocs) addText(line string, afterTags bool) bool {
+	if afterTags && isBlank(line) && (len(docs.text) == 0 || isBlank(docs.text[len(docs.text)-1])) {
+		return false
+	}
+	docs.text = append(docs.text, line)
+	return true
+}
+
+// isBlank returns true if the line contains only whitespace.
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// splitTag returns the tag and the value of a documentation line. If the line does not start with
+// a known tag, the tag is an empty string.
+func splitTag(line string) (string, string) {
+	for _, tag := range []string{tagArg, tagAuthor, tagSince, tagSee, tagLink} {
+		if line == tag || strings.HasPrefix(line, tag+" ") {
+			return tag, strings.TrimSpace(strings.TrimPrefix(line, tag))
+		}
+	}
+	return "", line
+}
+
+// splitArgument splits the value of an `@arg` tag into name, type and description.
+func splitArgument(value string) []string {
+	argument := strings.SplitN(value, " ", 3)
+	for len(argument) < 3 {
+		argument = append(argument, "")
+	}
+	return argument
+}
+
+// link translates the value of a `@link` tag (url followed by an optional text) into a link of
+// the output format (e.g. an AsciiDoc link macro).
+func (cf *CodeFile) link(value string) string {
+	url, text, _ := strings.Cut(value, " ")
+	return cf.docsRenderer().Link(url, strings.TrimSpace(text))
+}
+
+// splitSee splits the value of a `@see` tag at the first whitespace into the target and the
+// optional label.
+func splitSee(value string) (string, string) {
+	i := strings.IndexFunc(value, unicode.IsSpace)
+	if i < 0 {
+		return value, ""
+	}
+	return value[:i], strings.TrimSpace(value[i:])
+}
+
+// xref translates the target of a `@see` tag into a cross reference of the output format (e.g. an
+// AsciiDoc xref). Targets are expected relative to the code file or relative to the working
+// directory and are resolved to the documentation page of the first existing file. URLs are
+// translated into links. Without label, the target is used as text.
+func (cf *CodeFile) xref(target string, label string) string {
+	if strings.Contains(target, "://") {
+		return cf.link(strings.TrimSpace(target + " " + label))
+	}
+	if label == "" {
+		label = target
+	}
+
+	relativeToFile := filepath.Clean(filepath.Join(cf.path, target))
+	resolved := relativeToFile
+	for _, candidate := range []string{relativeToFile, filepath.Clean(target)} {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			resolved = candidate
+			break
+		}
+	}
+
+	dir, name := splitPathAndFilename(resolved)
+	page := &CodeFile{path: dir, name: name, renderer: cf.renderer}
+	return cf.docsRenderer().Xref(cf.documentationPage(), page.documentationPage(), label)
+}
+
+// isEmpty returns true if the documentation block contains neither text nor tags.
+func (docs *taggedDocs) isEmpty() bool {
+	return len(docs.text) == 0 && len(docs.arguments) == 0 && len(docs.metadata) == 0 && len(docs.see) == 0
+}
+
+// render translates the documentation block into the output format. Metadata tags are not part
+// of the result because their placement depends on the type of the documentation block.
+func (docs *taggedDocs) render(renderer render.Renderer) string {
+	content := ""
+	for _, line := range docs.text {
+		content += line + "\n"
+	}
+
+	if len(docs.arguments) > 0 {
+		content += "\n"
+		content += renderer.Table(render.Table{
+			Title:  "Arguments",
+			Cols:   []int{1, 1, 5},
+			Header: []string{"Name", "Type", "Description"},
+			Rows:   docs.arguments,
+		})
+	}
+
+	if len(docs.see) > 0 {
+		xrefs := []string{}
+		for _, see := range docs.see {
+			xrefs = append(xrefs, see.xref)
+		}
+		content += "\n"
+		content += renderer.List("See also", xrefs)
+	}
+	return content
+}
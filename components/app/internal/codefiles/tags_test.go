@@ -0,0 +1,169 @@
+package codefiles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldSplitTags(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		line          string
+		expectedTag   string
+		expectedValue string
+	}{
+		{line: "@arg $1 string Lorem ipsum", expectedTag: tagArg, expectedValue: "$1 string Lorem ipsum"},
+		{line: "@author Sebastian Sommerfeld", expectedTag: tagAuthor, expectedValue: "Sebastian Sommerfeld"},
+		{line: "@since 1.0.0", expectedTag: tagSince, expectedValue: "1.0.0"},
+		{line: "@see lib/util.sh", expectedTag: tagSee, expectedValue: "lib/util.sh"},
+		{line: "@link https://sommerfeld.io Website", expectedTag: tagLink, expectedValue: "https://sommerfeld.io Website"},
+		{line: "@arguments are no tags", expectedTag: "", expectedValue: "@arguments are no tags"},
+		{line: "Lorem ipsum @since 1.0.0", expectedTag: "", expectedValue: "Lorem ipsum @since 1.0.0"},
+	}
+
+	for _, test := range tests {
+		tag, value := splitTag(test.line)
+		assert.Equal(test.expectedTag, tag, "Incorrect tag for: "+test.line)
+		assert.Equal(test.expectedValue, value, "Incorrect value for: "+test.line)
+	}
+}
+
+func Test_ShouldTranslateLinks(t *testing.T) {
+	assert := assert.New(t)
+
+	script := NewCodeFile("src/main/script.sh")
+	assert.Equal("link:https://sommerfeld.io[Website]", script.link("https://sommerfeld.io Website"))
+	assert.Equal("link:https://sommerfeld.io[]", script.link("https://sommerfeld.io"))
+
+	script.SetRenderer(&render.Markdown{})
+	assert.Equal("[Website](https://sommerfeld.io)", script.link("https://sommerfeld.io Website"))
+	assert.Equal("<https://sommerfeld.io>", script.link("https://sommerfeld.io"))
+}
+
+func Test_ShouldResolveXrefs(t *testing.T) {
+	assert := assert.New(t)
+
+	chdir(t, t.TempDir())
+	createFiles(t, ".", map[string]string{
+		"src/main/script.sh": "",
+		"src/lib/util.sh":    "",
+	})
+	script := NewCodeFile("src/main/script.sh")
+
+	tests := []struct {
+		target   string
+		label    string
+		expected string
+	}{
+		{target: "../lib/util.sh", expected: "xref:src/lib/util-sh.adoc[../lib/util.sh]"},
+		{target: "src/lib/util.sh", expected: "xref:src/lib/util-sh.adoc[src/lib/util.sh]"},
+		{target: "unknown.sh", expected: "xref:src/main/unknown-sh.adoc[unknown.sh]"},
+		{target: "src/lib/unknown.sh", expected: "xref:src/main/src/lib/unknown-sh.adoc[src/lib/unknown.sh]"},
+		{target: "../lib/util.sh", label: "for details", expected: "xref:src/lib/util-sh.adoc[for details]"},
+		{target: "https://sommerfeld.io", expected: "link:https://sommerfeld.io[]"},
+		{target: "https://sommerfeld.io", label: "Website", expected: "link:https://sommerfeld.io[Website]"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, script.xref(test.target, test.label), "Incorrect xref for: "+test.target)
+	}
+}
+
+func Test_ShouldParseTagsFromHeaderDocs(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		path: "src",
+		name: "script.sh",
+		lang: LanguageBash,
+		fileContent: `#!/bin/bash
+## Lorem ipsum dolor sit amet.
+## @link https://sommerfeld.io Website
+##
+## @author Sebastian Sommerfeld
+## @since 1.0.0
+## @arg $1 string Lorem ipsum
+## @arg $2 int Value with | pipe
+## @see other.sh
+`,
+		documentationParts: []DocumentationPart{},
+	}
+
+	expectedDocs := `= script.sh
+
+[cols="1,5"]
+|===
+|Language |` + LanguageBash + `
+|Path |src/script.sh
+|Author |Sebastian Sommerfeld
+|Since |1.0.0
+|===
+
+Lorem ipsum dolor sit amet.
+link:https://sommerfeld.io[Website]
+
+.Arguments
+[cols="1,1,5"]
+|===
+|Name |Type |Description
+
+|$1 |string |Lorem ipsum
+|$2 |int |Value with \| pipe
+|===
+
+.See also
+* xref:src/other-sh.adoc[other.sh]
+`
+
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+	assert.Equal(expectedDocs, codeFile.parsedDocumentation(), "Incorrect parsed documentation")
+}
+
+func Test_ShouldSplitSeeTargetAndLabel(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := NewCodeFile("src/script.sh")
+	docs := codeFile.parseTags([]string{"@see other.sh for details", "@see lib/util.sh", "@see ../other.sh\tfor  details"})
+
+	assert.Equal([]seeTag{
+		{target: "other.sh", xref: "xref:src/other-sh.adoc[for details]"},
+		{target: "lib/util.sh", xref: "xref:src/lib/util-sh.adoc[lib/util.sh]"},
+		{target: "../other.sh", xref: "xref:other-sh.adoc[for  details]"},
+	}, docs.see, "Incorrect see tags")
+}
+
+func Test_ShouldCollapseBlankLinesLeftByTags(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		lines    []string
+		expected []string
+	}{
+		{
+			lines:    []string{"Lorem ipsum.", "", "@author Sebastian Sommerfeld", "", "@since 1.0.0", "", "Dolor sit amet."},
+			expected: []string{"Lorem ipsum.", "", "Dolor sit amet."},
+		},
+		{
+			lines:    []string{"@author Sebastian Sommerfeld", "", "Lorem ipsum."},
+			expected: []string{"Lorem ipsum."},
+		},
+		{
+			lines:    []string{"Lorem ipsum.", "", "@author Sebastian Sommerfeld", "", "@see other.sh", ""},
+			expected: []string{"Lorem ipsum."},
+		},
+		{
+			lines:    []string{"Lorem ipsum.", "", "", "Dolor sit amet."},
+			expected: []string{"Lorem ipsum.", "", "", "Dolor sit amet."},
+		},
+	}
+
+	codeFile := NewCodeFile("src/script.sh")
+	for _, test := range tests {
+		assert.Equal(test.expected, codeFile.parseTags(test.lines).text, "Incorrect text for: "+strings.Join(test.lines, "|"))
+	}
+}
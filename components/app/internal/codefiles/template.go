@@ -0,0 +1,134 @@
+package codefiles
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+)
+
+// defaultTemplateContent is the default page layout. It writes all DocumentationParts in the order
+// of their creation.
+//
+//go:embed templates/default.tmpl
+var defaultTemplateContent string
+
+// DefaultTemplate is the page template used for all code files without a user-supplied template.
+var DefaultTemplate = template.Must(template.New("default.tmpl").Parse(defaultTemplateContent))
+
+// PageData is passed to the page templates. It provides the CodeFile (e.g. `{{ .File.Filename }}`)
+// and all its DocumentationParts (e.g. `{{ range .Parts }}{{ .SectionContent }}{{ end }}`).
+type PageData struct {
+	File  *CodeFile
+	Page  string
+	Parts []DocumentationPart
+}
+
+// PartsOfType returns all DocumentationParts of the given type, e.g. `{{ range .PartsOfType "function" }}`.
+func (data *PageData) PartsOfType(sectionType string) []DocumentationPart {
+	parts := []DocumentationPart{}
+	for _, part := range data.Parts {
+		if part.sectionType == sectionType {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// PageTemplates holds the user-supplied page templates. A global template is used for all
+// languages, a language specific template only for the code files of this language. Languages
+// without template use the DefaultTemplate.
+type PageTemplates struct {
+	global    *template.Template
+	languages map[string]*template.Template
+	digest    hash.Hash
+}
+
+// NewPageTemplates reads the templates from the given specs. A spec is either the path to a
+// global template (e.g. `templates/page.tmpl`) or a mapping of a language to the path of a
+// template (e.g. `sh=templates/script.tmpl`). If multiple specs apply to the same language, the
+// last one wins.
+func NewPageTemplates(specs []string) (*PageTemplates, error) {
+	templates := &PageTemplates{
+		global:    DefaultTemplate,
+		languages: map[string]*template.Template{},
+		digest:    sha256.New(),
+	}
+	for _, spec := range specs {
+		lang, path, ok := strings.Cut(spec, "=")
+		if !ok {
+			lang, path = "", spec
+		}
+		if _, supported := SupportedLanguages.ByName(lang); lang != "" && !supported {
+			return nil, fmt.Errorf("unsupported language for template: %s", spec)
+		}
+
+		tmpl, content, err := readTemplate(path)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(templates.digest, "%s\n%s\n", spec, content)
+		if lang == "" {
+			templates.global = tmpl
+		} else {
+			templates.languages[lang] = tmpl
+		}
+	}
+	return templates, nil
+}
+
+// readTemplate reads and parses a template file. The raw content of the file is returned as well.
+func readTemplate(path string) (*template.Template, string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read template: %v", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Parse(string(content))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse template: %v", err)
+	}
+	return tmpl, string(content), nil
+}
+
+// ForLanguage returns the page template for the given language.
+func (templates *PageTemplates) ForLanguage(lang string) *template.Template {
+	if tmpl, ok := templates.languages[lang]; ok {
+		return tmpl
+	}
+	return templates.global
+}
+
+// Hash returns a hash of the specs and the content of all user-supplied templates. The hash
+// changes whenever a template changes.
+func (templates *PageTemplates) Hash() string {
+	return hex.EncodeToString(templates.digest.Sum(nil))
+}
+
+// renderPage executes the page template of the CodeFile with all its DocumentationParts. The page
+// starts with the output.GeneratedHeader, so it is recognized as generated page later.
+func (cf *CodeFile) renderPage() (string, error) {
+	tmpl := cf.template
+	if tmpl == nil {
+		tmpl = DefaultTemplate
+	}
+
+	data := &PageData{
+		File:  cf,
+		Page:  cf.documentationPage(),
+		Parts: cf.documentationParts,
+	}
+	content := &strings.Builder{}
+	err := tmpl.Execute(content, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to execute template %s: %v", tmpl.Name(), err)
+	}
+	return cf.docsRenderer().Comment(output.GeneratedHeader) + content.String(), nil
+}
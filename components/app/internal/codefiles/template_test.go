@@ -0,0 +1,107 @@
+package codefiles
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldRenderPageWithDefaultTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := NewCodeFile("src/script.sh")
+	codeFile.fileContent = "## Lorem ipsum\n\n## Greet someone.\ngreet() {\n}\n"
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	page, err := codeFile.renderPage()
+	assert.Nil(err, "Error rendering page")
+	assert.Equal("// "+output.GeneratedHeader+"\n"+codeFile.parsedDocumentation(), page, "Default template should contain all parts")
+}
+
+func Test_ShouldRenderPageWithCustomTemplates(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"page.tmpl":   `{{ range .PartsOfType "title" }}{{ .SectionContent }}{{ end }}:page-edit-url: https://example.com/{{ .File.Path }}/{{ .File.Filename }}` + "\n",
+		"script.tmpl": `{{ .Page }}{{ range .PartsOfType "function" }} {{ .Name }}{{ end }}` + "\n",
+	})
+
+	templates, err := NewPageTemplates([]string{filepath.Join(dir, "page.tmpl"), "sh=" + filepath.Join(dir, "script.tmpl")})
+	assert.Nil(err, "Error reading templates")
+
+	tests := []struct {
+		path     string
+		content  string
+		expected string
+	}{
+		{path: "src/script.sh", content: "## Lorem ipsum\n\n## Greet someone.\ngreet() {\n}\n", expected: "src/script-sh.adoc greet\n"},
+		{path: "src/Makefile", content: "## Lorem ipsum\n", expected: "= Makefile\n:page-edit-url: https://example.com/src/Makefile\n"},
+	}
+
+	for _, test := range tests {
+		codeFile := NewCodeFile(test.path)
+		codeFile.fileContent = test.content
+		codeFile.SetPageTemplate(templates.ForLanguage(codeFile.Language()))
+		err := codeFile.Parse()
+		assert.Nil(err, "Error parsing documentation")
+
+		page, err := codeFile.renderPage()
+		assert.Nil(err, "Error rendering page")
+		assert.Equal("// "+output.GeneratedHeader+"\n"+test.expected, page, "Incorrect page for "+test.path)
+	}
+}
+
+func Test_ShouldHashPageTemplates(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "page.tmpl")
+	createFiles(t, dir, map[string]string{"page.tmpl": "{{ .Page }}\n"})
+
+	defaults, err := NewPageTemplates([]string{})
+	assert.Nil(err, "Error reading templates")
+	before, err := NewPageTemplates([]string{path})
+	assert.Nil(err, "Error reading templates")
+	again, err := NewPageTemplates([]string{path})
+	assert.Nil(err, "Error reading templates")
+
+	createFiles(t, dir, map[string]string{"page.tmpl": "{{ .File.Filename }}\n"})
+	after, err := NewPageTemplates([]string{path})
+	assert.Nil(err, "Error reading templates")
+
+	assert.Equal(before.Hash(), again.Hash(), "Same templates should result in the same hash")
+	assert.NotEqual(defaults.Hash(), before.Hash(), "Custom template should change the hash")
+	assert.NotEqual(before.Hash(), after.Hash(), "Changed template should change the hash")
+}
+
+func Test_ShouldFailForInvalidTemplates(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFiles(t, dir, map[string]string{
+		"invalid.tmpl": "{{ range .Parts }",
+		"unknown.tmpl": "{{ .Unknown }}",
+	})
+
+	specs := []string{
+		filepath.Join(dir, "missing.tmpl"),
+		filepath.Join(dir, "invalid.tmpl"),
+		"go=" + filepath.Join(dir, "unknown.tmpl"),
+	}
+	for _, spec := range specs {
+		_, err := NewPageTemplates([]string{spec})
+		assert.NotNil(err, "Error should be reported for "+spec)
+	}
+
+	templates, err := NewPageTemplates([]string{filepath.Join(dir, "unknown.tmpl")})
+	assert.Nil(err, "Error reading templates")
+
+	codeFile := NewCodeFile("src/script.sh")
+	codeFile.SetPageTemplate(templates.ForLanguage(codeFile.Language()))
+	_, err = codeFile.renderPage()
+	assert.NotNil(err, "Unknown fields should be reported")
+}
@@ -0,0 +1,150 @@
+package codefiles
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+)
+
+// yamlKeyPattern matches lines of a YAML mapping like `key: value`, `key:` and `- key: value`.
+// The groups are the indentation, the list item marker, the key and the value.
+var yamlKeyPattern = regexp.MustCompile(`^(\s*)(-\s+)?([^\s:#'"-][^:#]*?|"[^"]*"|'[^']*')\s*:(?:\s+(.*))?$`)
+
+// yamlKey represents a mapping key of a YAML file. The path is the fully qualified key path,
+// e.g. `services.web.image`. Keys inside list items are marked with `[]`, e.g. `items[].name`.
+type yamlKey struct {
+	indent int
+	path   string
+	item   bool
+}
+
+// yamlKeyTracker keeps track of the parent keys while reading a YAML file line by line.
+type yamlKeyTracker struct {
+	parents     []yamlKey
+	blockIndent int
+}
+
+func newYamlKeyTracker() *yamlKeyTracker {
+	return &yamlKeyTracker{
+		parents:     []yamlKey{},
+		blockIndent: -1,
+	}
+}
+
+// next processes a line of the YAML file and returns the fully qualified path and the value of
+// the key defined in this line. If the line does not define a key, ok is false.
+func (tracker *yamlKeyTracker) next(line string) (path string, value string, ok bool) {
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	if tracker.blockIndent >= 0 && (strings.TrimSpace(line) == "" || indent > tracker.blockIndent) {
+		return "", "", false
+	}
+	tracker.blockIndent = -1
+
+	if strings.TrimSpace(line) == "---" {
+		tracker.parents = []yamlKey{}
+		return "", "", false
+	}
+
+	matches := yamlKeyPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return "", "", false
+	}
+
+	keyIndent := len(matches[1])
+	if matches[2] != "" {
+		tracker.pushItem(keyIndent)
+		keyIndent += len(matches[2])
+	}
+
+	tracker.pop(func(parent yamlKey) bool { return parent.indent >= keyIndent })
+	path = strings.Trim(matches[3], `"'`)
+	if len(tracker.parents) > 0 {
+		path = tracker.parents[len(tracker.parents)-1].path + "." + path
+	}
+	tracker.parents = append(tracker.parents, yamlKey{indent: keyIndent, path: path})
+
+	value = yamlValue(matches[4])
+	if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+		tracker.blockIndent = keyIndent
+		value = ""
+	}
+	return path, value, true
+}
+
+// pushItem marks the start of a new list item. Keys of a previous list item on the same level are
+// no parents of the new list item.
+func (tracker *yamlKeyTracker) pushItem(indent int) {
+	tracker.pop(func(parent yamlKey) bool {
+		return parent.indent > indent || (parent.indent == indent && parent.item)
+	})
+
+	path := "[]"
+	if len(tracker.parents) > 0 {
+		path = tracker.parents[len(tracker.parents)-1].path + "[]"
+	}
+	tracker.parents = append(tracker.parents, yamlKey{indent: indent, path: path, item: true})
+}
+
+// pop removes parents from the top of the stack as long as the condition is true.
+func (tracker *yamlKeyTracker) pop(condition func(parent yamlKey) bool) {
+	for len(tracker.parents) > 0 && condition(tracker.parents[len(tracker.parents)-1]) {
+		tracker.parents = tracker.parents[:len(tracker.parents)-1]
+	}
+}
+
+// yamlValue returns the value of a key without trailing comments.
+func yamlValue(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		end := strings.Index(value[1:], value[:1])
+		if end >= 0 {
+			return value[:end+2]
+		}
+		return value
+	}
+
+	value, _, _ = strings.Cut(value, " #")
+	return strings.TrimSpace(value)
+}
+
+// parseYamlKeys finds all mapping keys of a YAML file which are documented by comments (marked
+// with `##`) immediately preceding the key. The keys are returned as table with their fully
+// qualified key path and their default value.
+//
+// See "Rules for the YAML key documentation" in `docs/modules/ROOT/pages/index.adoc`.
+func (cf *CodeFile) parseYamlKeys() []DocumentationPart {
+	rows := [][]string{}
+	keyDocs := []string{}
+	tracker := newYamlKeyTracker()
+	for _, line := range strings.Split(cf.fileContent, "\n") {
+		if text, ok := cf.docLine(strings.TrimSpace(line)); ok {
+			keyDocs = append(keyDocs, text)
+			continue
+		}
+
+		path, value, ok := tracker.next(line)
+		if ok && len(keyDocs) > 0 {
+			rows = append(rows, []string{path, value, strings.Join(keyDocs, " ")})
+		}
+		keyDocs = []string{}
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+	return []DocumentationPart{NewDocumentationPart(DocumentationPartKeys, renderYamlKeys(cf.docsRenderer(), rows))}
+}
+
+// renderYamlKeys renders the documented keys of a YAML file as a table.
+func renderYamlKeys(renderer render.Renderer, rows [][]string) string {
+	content := "\n"
+	content += renderer.Heading(1, "Keys")
+	content += "\n"
+	content += renderer.Table(render.Table{
+		Cols:   []int{2, 2, 5},
+		Header: []string{"Key", "Default Value", "Description"},
+		Rows:   rows,
+	})
+	return content
+}
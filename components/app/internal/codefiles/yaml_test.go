@@ -0,0 +1,96 @@
+package codefiles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldTrackYamlKeyPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	content := `---
+services:
+  web:
+    image: "nginx:latest" # trailing comment
+    ports:
+      - name: http
+        port: 80
+      - name: https
+    command: |
+      not: a key
+  db:
+    image: postgres
+---
+version: 1
+`
+	expected := [][]string{
+		{"services", ""},
+		{"services.web", ""},
+		{"services.web.image", `"nginx:latest"`},
+		{"services.web.ports", ""},
+		{"services.web.ports[].name", "http"},
+		{"services.web.ports[].port", "80"},
+		{"services.web.ports[].name", "https"},
+		{"services.web.command", ""},
+		{"services.db", ""},
+		{"services.db.image", "postgres"},
+		{"version", "1"},
+	}
+
+	keys := [][]string{}
+	tracker := newYamlKeyTracker()
+	for _, line := range strings.Split(content, "\n") {
+		path, value, ok := tracker.next(line)
+		if ok {
+			keys = append(keys, []string{path, value})
+		}
+	}
+	assert.Equal(expected, keys, "Incorrect key paths")
+}
+
+func Test_ShouldParseYamlKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	codeFile := &CodeFile{
+		name: "values.yml",
+		lang: LanguageYml,
+		fileContent: `---
+## Header docs
+
+services:
+  web:
+    ## The image of the web server.
+    ## Use a pinned version in production.
+    image: nginx:latest
+
+    ## Not directly above a key
+
+    restart: always
+  ## List of exposed ports.
+  ports:
+    - "8080:80"
+`,
+		documentationParts: []DocumentationPart{},
+	}
+
+	expected := `
+== Keys
+
+[cols="2,2,5"]
+|===
+|Key |Default Value |Description
+
+|services.web.image |nginx:latest |The image of the web server. Use a pinned version in production.
+|services.ports | |List of exposed ports.
+|===
+`
+
+	err := codeFile.Parse()
+	assert.Nil(err, "Error parsing documentation")
+
+	part := codeFile.documentationParts[len(codeFile.documentationParts)-1]
+	assert.Equal(DocumentationPartKeys, part.SectionType(), "Incorrect section type")
+	assert.Equal(expected, part.SectionContent(), "Incorrect keys table")
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/sommerfeld-io/source2adoc/internal/render"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFilename is the name of the config file which is discovered in the working directory.
+const DefaultFilename = ".source2adoc.yml"
+
+// Config represents the project configuration. All settings can be defined in a config file.
+// CLI flags override the values from the config file.
+type Config struct {
+	SourceDirs  []string `yaml:"source-dirs"`
+	OutputDir   string   `yaml:"output-dir"`
+	Format      string   `yaml:"format"`
+	Include     []string `yaml:"include"`
+	Exclude     []string `yaml:"exclude"`
+	Languages   []string `yaml:"languages"`
+	Templates   []string `yaml:"templates"`
+	Gitignore   bool     `yaml:"gitignore"`
+	Prune       bool     `yaml:"prune"`
+	Jobs        int      `yaml:"jobs"`
+	MinCoverage float64  `yaml:"min-coverage"`
+}
+
+// New acts as a constructor for a new and empty Config instance.
+func New() *Config {
+	return &Config{
+		SourceDirs: []string{},
+		Include:    []string{},
+		Exclude:    []string{},
+		Languages:  []string{},
+		Templates:  []string{},
+		Format:     render.FormatAsciiDoc,
+		Jobs:       runtime.NumCPU(),
+	}
+}
+
+// Load reads the config from the given file. If no file is given, the DefaultFilename is
+// discovered in the working directory. If there is no config file in the working directory, an
+// empty Config is returned. An explicitly given file must exist.
+func Load(path string) (*Config, error) {
+	if path == "" {
+		_, err := os.Stat(DefaultFilename)
+		if errors.Is(err, os.ErrNotExist) {
+			return New(), nil
+		}
+		path = DefaultFilename
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+	return Parse(content)
+}
+
+// Parse reads the config from the given YAML content. Unknown keys are reported as error to
+// detect typos in the config file.
+func Parse(content []byte) (*Config, error) {
+	cfg := New()
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file: %v", err)
+	}
+	return cfg, nil
+}
+
+// Validate checks if all mandatory settings for generating documentation are present and valid.
+func (cfg *Config) Validate() error {
+	err := cfg.ValidateSources()
+	if err != nil {
+		return err
+	}
+	if cfg.OutputDir == "" {
+		return fmt.Errorf("no output dir configured: use --output-dir or the config file")
+	}
+	if cfg.Jobs < 1 {
+		return fmt.Errorf("invalid number of jobs: %d", cfg.Jobs)
+	}
+	return nil
+}
+
+// ValidateSources checks if all mandatory settings for commands which only read the code files
+// (e.g. linting) are present and valid. These commands do not write anything, so no output dir is
+// needed.
+func (cfg *Config) ValidateSources() error {
+	if len(cfg.SourceDirs) == 0 {
+		return fmt.Errorf("no source dir configured: use --source-dir or the config file")
+	}
+	return nil
+}
+
+// ValidateLint checks if all mandatory settings for linting the code files are present and valid.
+// Besides the sources, the minimum coverage must be a percentage.
+func (cfg *Config) ValidateLint() error {
+	err := cfg.ValidateSources()
+	if err != nil {
+		return err
+	}
+	if cfg.MinCoverage < 0 || cfg.MinCoverage > 100 {
+		return fmt.Errorf("invalid minimum coverage: %v", cfg.MinCoverage)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldParseConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	content := `
+source-dirs:
+  - src
+  - scripts
+output-dir: docs
+format: markdown
+include:
+  - src
+  - scripts/bin
+exclude:
+  - src/vendor
+languages:
+  - scripts/bin/*=sh
+templates:
+  - templates/page.tmpl
+  - sh=templates/script.tmpl
+gitignore: true
+prune: true
+jobs: 4
+min-coverage: 80.5
+`
+	cfg, err := Parse([]byte(content))
+	assert.Nil(err, "Error parsing config")
+	assert.Equal([]string{"src", "scripts"}, cfg.SourceDirs, "Incorrect source dirs")
+	assert.Equal("docs", cfg.OutputDir, "Incorrect output dir")
+	assert.Equal("markdown", cfg.Format, "Incorrect format")
+	assert.Equal([]string{"src", "scripts/bin"}, cfg.Include, "Incorrect includes")
+	assert.Equal([]string{"src/vendor"}, cfg.Exclude, "Incorrect excludes")
+	assert.Equal([]string{"scripts/bin/*=sh"}, cfg.Languages, "Incorrect language mappings")
+	assert.Equal([]string{"templates/page.tmpl", "sh=templates/script.tmpl"}, cfg.Templates, "Incorrect templates")
+	assert.True(cfg.Gitignore, "Incorrect gitignore setting")
+	assert.True(cfg.Prune, "Incorrect prune setting")
+	assert.Equal(4, cfg.Jobs, "Incorrect number of jobs")
+	assert.Equal(80.5, cfg.MinCoverage, "Incorrect minimum coverage")
+}
+
+func Test_ShouldParseEmptyConfig(t *testing.T) {
+	cfg, err := Parse([]byte(""))
+	assert.Nil(t, err, "Error parsing config")
+	assert.Equal(t, New(), cfg, "Config should be empty")
+}
+
+func Test_ShouldFailToParseUnknownKeys(t *testing.T) {
+	_, err := Parse([]byte("source-dir: src\n"))
+	assert.NotNil(t, err, "Unknown keys should be reported")
+}
+
+func Test_ShouldLoadConfigFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	err := os.WriteFile(path, []byte("output-dir: docs\n"), 0644)
+	assert.Nil(err, "Error writing config file")
+
+	cfg, err := Load(path)
+	assert.Nil(err, "Error loading config")
+	assert.Equal("docs", cfg.OutputDir, "Incorrect output dir")
+}
+
+func Test_ShouldFailToLoadMissingConfigFile(t *testing.T) {
+	_, err := Load(filepath.Join(t.TempDir(), "missing.yml"))
+	assert.NotNil(t, err, "Missing config file should be reported")
+}
+
+func Test_ShouldDiscoverConfigFileInWorkingDir(t *testing.T) {
+	assert := assert.New(t)
+
+	workingDir, err := os.Getwd()
+	assert.Nil(err, "Error getting working dir")
+	err = os.Chdir(t.TempDir())
+	assert.Nil(err, "Error changing working dir")
+	defer os.Chdir(workingDir)
+
+	cfg, err := Load("")
+	assert.Nil(err, "Missing default config file should not be reported")
+	assert.Equal(New(), cfg, "Config should be empty")
+
+	err = os.WriteFile(DefaultFilename, []byte("source-dirs: [src]\n"), 0644)
+	assert.Nil(err, "Error writing config file")
+
+	cfg, err = Load("")
+	assert.Nil(err, "Error loading config")
+	assert.Equal([]string{"src"}, cfg.SourceDirs, "Incorrect source dirs")
+}
+
+func Test_ShouldValidateConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := New()
+	assert.NotNil(cfg.Validate(), "Missing source dir should be reported")
+
+	cfg.SourceDirs = []string{"src"}
+	assert.NotNil(cfg.Validate(), "Missing output dir should be reported")
+
+	cfg.OutputDir = "docs"
+	assert.Nil(cfg.Validate(), "Config should be valid")
+
+	cfg.Jobs = 0
+	assert.NotNil(cfg.Validate(), "Invalid number of jobs should be reported")
+}
+
+func Test_ShouldValidateSourcesConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := New()
+	assert.NotNil(cfg.ValidateSources(), "Missing source dir should be reported")
+
+	cfg.SourceDirs = []string{"src"}
+	assert.Nil(cfg.ValidateSources(), "Config without output dir should be valid")
+
+	cfg.MinCoverage = 101
+	assert.Nil(cfg.ValidateSources(), "Minimum coverage should only be checked for linting")
+}
+
+func Test_ShouldValidateLintConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	cfg := New()
+	assert.NotNil(cfg.ValidateLint(), "Missing source dir should be reported")
+
+	cfg.SourceDirs = []string{"src"}
+	assert.Nil(cfg.ValidateLint(), "Config should be valid")
+
+	for _, minCoverage := range []float64{-1, 100.5} {
+		cfg.MinCoverage = minCoverage
+		assert.NotNil(cfg.ValidateLint(), fmt.Sprintf("Invalid minimum coverage should be reported: %v", minCoverage))
+	}
+	cfg.MinCoverage = 100
+	assert.Nil(cfg.ValidateLint(), "Minimum coverage of 100 should be valid")
+}
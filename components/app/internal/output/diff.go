@@ -0,0 +1,121 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change of a diff.
+const diffContext = 3
+
+// diffLine represents a single line of a diff. The kind is ` ` for unchanged lines, `-` for
+// removed lines and `+` for added lines. The indexes count the lines of the old and the new
+// text preceding this line.
+type diffLine struct {
+	kind     byte
+	text     string
+	oldIndex int
+	newIndex int
+}
+
+// Diff returns the unified diff between the current content of a file and its generated content.
+// If both are equal, the diff is empty.
+func Diff(path string, current string, generated string) string {
+	if current == generated {
+		return ""
+	}
+
+	lines := diffLines(splitLines(current), splitLines(generated))
+	diff := "--- " + path + "\n"
+	diff += "+++ " + path + " (generated)\n"
+	for _, hunk := range diffHunks(lines) {
+		diff += renderHunk(lines[hunk[0]:hunk[1]])
+	}
+	return diff
+}
+
+// splitLines splits a text into lines. A trailing newline does not result in an empty line.
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines calculates the differences between the old and the new lines based on their longest
+// common subsequence.
+func diffLines(oldLines []string, newLines []string) []diffLine {
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := []diffLine{}
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			lines = append(lines, diffLine{kind: ' ', text: oldLines[i], oldIndex: i, newIndex: j})
+			i, j = i+1, j+1
+		case i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{kind: '-', text: oldLines[i], oldIndex: i, newIndex: j})
+			i++
+		default:
+			lines = append(lines, diffLine{kind: '+', text: newLines[j], oldIndex: i, newIndex: j})
+			j++
+		}
+	}
+	return lines
+}
+
+// diffHunks returns the ranges (start and end index) of the lines which are part of a hunk. Each
+// hunk contains the changed lines and the surrounding context. Overlapping hunks are merged.
+func diffHunks(lines []diffLine) [][2]int {
+	hunks := [][2]int{}
+	for i, line := range lines {
+		if line.kind == ' ' {
+			continue
+		}
+
+		start, end := max(0, i-diffContext), min(len(lines), i+diffContext+1)
+		if len(hunks) > 0 && start <= hunks[len(hunks)-1][1] {
+			hunks[len(hunks)-1][1] = end
+			continue
+		}
+		hunks = append(hunks, [2]int{start, end})
+	}
+	return hunks
+}
+
+// renderHunk renders the lines of a hunk with the `@@ -start,count +start,count @@` header.
+func renderHunk(lines []diffLine) string {
+	oldCount, newCount := 0, 0
+	body := ""
+	for _, line := range lines {
+		if line.kind != '+' {
+			oldCount++
+		}
+		if line.kind != '-' {
+			newCount++
+		}
+		body += string(line.kind) + line.text + "\n"
+	}
+
+	oldStart, newStart := lines[0].oldIndex, lines[0].newIndex
+	if oldCount > 0 {
+		oldStart++
+	}
+	if newCount > 0 {
+		newStart++
+	}
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount) + body
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldCreateUnifiedDiff(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		current   string
+		generated string
+		expected  string
+	}{
+		{
+			current:   "a\nb\nc\n",
+			generated: "a\nb\nc\n",
+			expected:  "",
+		},
+		{
+			current:   "a\nb\nc\n",
+			generated: "a\nB\nc\n",
+			expected:  "--- f\n+++ f (generated)\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			current:   "",
+			generated: "a\n",
+			expected:  "--- f\n+++ f (generated)\n@@ -0,0 +1,1 @@\n+a\n",
+		},
+		{
+			current:   "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			generated: "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			expected:  "--- f\n+++ f (generated)\n@@ -1,3 +1,4 @@\n+0\n 1\n 2\n 3\n@@ -9,4 +10,3 @@\n 9\n 10\n 11\n-12\n",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, Diff("f", test.current, test.generated), "Incorrect diff")
+	}
+}
@@ -0,0 +1,46 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// File represents a file generated by the app (e.g. the documentation page of a code file). The
+// content is created in memory first, so it can be compared with the file system before anything
+// is written.
+type File struct {
+	Source  string
+	Path    string
+	Content string
+}
+
+// NewFile acts as a constructor for a new File instance. The source describes where the content
+// comes from (e.g. the path of the code file).
+func NewFile(source string, path string, content string) *File {
+	return &File{
+		Source:  source,
+		Path:    filepath.Clean(path),
+		Content: content,
+	}
+}
+
+// Write writes the content of the File to the file system. Missing directories are created.
+// Writing is silent, so the caller decides when to log the File (see String).
+func (file *File) Write() error {
+	err := os.MkdirAll(filepath.Dir(file.Path), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	err = os.WriteFile(file.Path, []byte(file.Content), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write file: %v", err)
+	}
+	return nil
+}
+
+// String describes the File by its source and its path.
+func (file *File) String() string {
+	return file.Source + "    ==>    " + file.Path
+}
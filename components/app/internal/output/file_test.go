@@ -0,0 +1,33 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldWriteFile(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "docs", "src", "script-sh.adoc")
+	file := NewFile("src/script.sh", path, "= script.sh\n")
+
+	err := file.Write()
+	assert.Nil(err, "Error writing file")
+
+	content, err := os.ReadFile(path)
+	assert.Nil(err, "Error reading file")
+	assert.Equal("= script.sh\n", string(content), "Incorrect content")
+}
+
+func Test_ShouldCleanPath(t *testing.T) {
+	file := NewFile("src/script.sh", "docs//src/./script-sh.adoc", "")
+	assert.Equal(t, "docs/src/script-sh.adoc", file.Path, "Path should be cleaned")
+}
+
+func Test_ShouldDescribeFile(t *testing.T) {
+	file := NewFile("src/script.sh", "docs/src/script-sh.adoc", "")
+	assert.Equal(t, "src/script.sh    ==>    docs/src/script-sh.adoc", file.String(), "Incorrect description")
+}
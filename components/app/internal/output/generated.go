@@ -0,0 +1,48 @@
+package output
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GeneratedHeader marks the files generated by the app. It is written as comment into the first
+// line of each documentation page, so generated files are recognized even if the output
+// directory has no manifest (see GeneratedFiles).
+const GeneratedHeader = "Generated by source2adoc - do not edit"
+
+// IsGenerated returns true if the first line of the content contains the GeneratedHeader.
+func IsGenerated(content string) bool {
+	firstLine, _, _ := strings.Cut(content, "\n")
+	return strings.Contains(firstLine, GeneratedHeader)
+}
+
+// GeneratedFiles returns the paths of all files inside dir and its subfolders which are generated
+// by the app (see IsGenerated), sorted by path. Hand-written files are not part of the result. A
+// missing dir contains no generated files.
+func GeneratedFiles(dir string) ([]string, error) {
+	files := []string{}
+	if !exists(dir) {
+		return files, nil
+	}
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if IsGenerated(string(content)) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search generated files: %v", err)
+	}
+	return files, nil
+}
@@ -0,0 +1,51 @@
+package output
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldRecognizeGeneratedContent(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		content  string
+		expected bool
+	}{
+		{content: "// " + GeneratedHeader + "\n= Title\n", expected: true},
+		{content: "<!-- " + GeneratedHeader + " -->\n# Title\n", expected: true},
+		{content: "= Title\n// " + GeneratedHeader + "\n", expected: false},
+		{content: "= Hand-written guide\n", expected: false},
+		{content: "", expected: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, IsGenerated(test.content), "Incorrect result for: "+test.content)
+	}
+}
+
+func Test_ShouldFindGeneratedFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"run-sh.adoc":      "// " + GeneratedHeader + "\n= run.sh\n",
+		"lib/util-sh.adoc": "// " + GeneratedHeader + "\n= util.sh\n",
+		"guide.adoc":       "= Hand-written guide\n",
+	}
+	for path, content := range files {
+		err := NewFile("", filepath.Join(dir, path), content).Write()
+		assert.Nil(err, "Error creating file")
+	}
+
+	generated, err := GeneratedFiles(dir)
+	assert.Nil(err, "Error finding generated files")
+	expected := []string{filepath.Join(dir, "lib", "util-sh.adoc"), filepath.Join(dir, "run-sh.adoc")}
+	assert.Equal(expected, generated, "Incorrect generated files")
+
+	generated, err = GeneratedFiles(filepath.Join(dir, "missing"))
+	assert.Nil(err, "Error finding generated files")
+	assert.Empty(generated, "Missing dir should contain no generated files")
+}
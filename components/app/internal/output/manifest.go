@@ -0,0 +1,227 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestFilename is the name of the manifest file inside the output directory.
+const ManifestFilename = ".source2adoc-manifest.json"
+
+// Manifest keeps track of all files generated into an output directory. Each file is recorded
+// with its source, so files whose source no longer exists can be removed without touching
+// hand-written files living in the same directory. Both are recorded relative to the output
+// directory, so the Manifest stays valid no matter which working directory the app is run from. The hash of the source allows to skip sources
+// which did not change since the previous run. The hashes are only valid as long as the version of
+// the app and the settings (see UseSettings) are the same.
+type Manifest struct {
+	dir      string
+	changed  bool
+	Version  string                   `json:"version"`
+	Settings string                   `json:"settings"`
+	Files    map[string]ManifestEntry `json:"files"`
+}
+
+// ManifestEntry represents a single generated file in the Manifest. The source is a slash-separated
+// path relative to the output directory.
+type ManifestEntry struct {
+	Source string `json:"source"`
+	Hash   string `json:"hash,omitempty"`
+}
+
+// LoadManifest reads the manifest of the given output directory. If the output directory has no
+// manifest yet, an empty Manifest is returned.
+func LoadManifest(dir string) (*Manifest, error) {
+	manifest := &Manifest{
+		dir:   dir,
+		Files: map[string]ManifestEntry{},
+	}
+
+	content, err := os.ReadFile(manifest.path())
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %v", err)
+	}
+
+	err = json.Unmarshal(content, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %v", manifest.path(), err)
+	}
+	return manifest, nil
+}
+
+// path returns the path of the manifest file.
+func (manifest *Manifest) path() string {
+	return filepath.Join(manifest.dir, ManifestFilename)
+}
+
+// UseSettings sets the version of the app and the hash of the settings the files are generated
+// with. If either differs from the previous run, all recorded hashes are discarded.
+func (manifest *Manifest) UseSettings(version string, settings string) {
+	if manifest.Version == version && manifest.Settings == settings {
+		return
+	}
+	for rel, entry := range manifest.Files {
+		manifest.Files[rel] = ManifestEntry{Source: entry.Source}
+	}
+	manifest.Version = version
+	manifest.Settings = settings
+	manifest.changed = true
+}
+
+// Add records the given file in the Manifest together with the hash of its source. The file is
+// recorded with its path relative to the output directory. Files which are already known are
+// updated.
+func (manifest *Manifest) Add(file *File, hash string) error {
+	rel, err := manifest.relativePath(file.Path)
+	if err != nil {
+		return fmt.Errorf("failed to add file to manifest: %v", err)
+	}
+	source, err := manifest.relativePath(file.Source)
+	if err != nil {
+		return fmt.Errorf("failed to add file to manifest: %v", err)
+	}
+	entry := ManifestEntry{Source: source, Hash: hash}
+	if previous, ok := manifest.Files[rel]; !ok || previous != entry {
+		manifest.Files[rel] = entry
+		manifest.changed = true
+	}
+	return nil
+}
+
+// IsUnchanged returns true if the file at the given path was generated from the given source with
+// the given hash by a previous run and still exists.
+func (manifest *Manifest) IsUnchanged(path string, source string, hash string) bool {
+	rel, err := manifest.relativePath(path)
+	if err != nil {
+		return false
+	}
+	source, err = manifest.relativePath(source)
+	if err != nil {
+		return false
+	}
+	entry, ok := manifest.Files[rel]
+	return ok && hash != "" && entry.Hash == hash && entry.Source == source && exists(path)
+}
+
+// relativePath returns the path relative to the output directory as used by the Manifest. Both
+// are resolved to absolute paths first, so relative and absolute paths can be mixed.
+func (manifest *Manifest) relativePath(path string) (string, error) {
+	dir, err := filepath.Abs(manifest.dir)
+	if err != nil {
+		return "", err
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// sourcePath returns the path of the recorded source. Just like the output directory, the path
+// is either absolute or relative to the working directory.
+func (manifest *Manifest) sourcePath(entry ManifestEntry) string {
+	return filepath.Join(manifest.dir, filepath.FromSlash(entry.Source))
+}
+
+// Orphans returns the paths of all recorded files whose source no longer exists, sorted by path.
+// Files which were removed from the output directory already are not part of the result.
+func (manifest *Manifest) Orphans() []string {
+	orphans := []string{}
+	for rel, entry := range manifest.Files {
+		if exists(manifest.sourcePath(entry)) {
+			continue
+		}
+		path := filepath.Join(manifest.dir, filepath.FromSlash(rel))
+		if exists(path) {
+			orphans = append(orphans, path)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans
+}
+
+// Prune deletes all recorded files whose source no longer exists and removes them from the
+// Manifest. Directories which are empty afterwards are removed as well.
+func (manifest *Manifest) Prune() error {
+	return manifest.PruneSources(func(source string) bool {
+		return !exists(source)
+	})
+}
+
+// PruneSources deletes all recorded files whose source matches the given function and removes them
+// from the Manifest, no matter if the source still exists. The function receives the path of the
+// source (see sourcePath). Directories which are empty afterwards are removed as well.
+func (manifest *Manifest) PruneSources(match func(source string) bool) error {
+	for rel, entry := range manifest.Files {
+		source := manifest.sourcePath(entry)
+		if !match(source) {
+			continue
+		}
+
+		path := filepath.Join(manifest.dir, filepath.FromSlash(rel))
+		err := os.Remove(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to delete orphaned file: %v", err)
+		}
+		if err == nil {
+			fmt.Println(source + "    ==>    deleted " + path)
+		}
+		delete(manifest.Files, rel)
+		manifest.changed = true
+		manifest.removeEmptyDirs(filepath.Dir(path))
+	}
+	return nil
+}
+
+// removeEmptyDirs removes the given directory and its parents as long as they are empty. The
+// output directory itself is never removed.
+func (manifest *Manifest) removeEmptyDirs(dir string) {
+	root := filepath.Clean(manifest.dir)
+	for dir != root && dir != "." && dir != string(filepath.Separator) {
+		if os.Remove(dir) != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// Changed returns true if the Manifest changed since it was loaded.
+func (manifest *Manifest) Changed() bool {
+	return manifest.changed
+}
+
+// Write writes the Manifest to the output directory.
+func (manifest *Manifest) Write() error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize manifest: %v", err)
+	}
+
+	err = os.MkdirAll(manifest.dir, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+
+	err = os.WriteFile(manifest.path(), append(content, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %v", err)
+	}
+	return nil
+}
+
+// exists returns true if the file exists.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
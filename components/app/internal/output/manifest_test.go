@@ -0,0 +1,214 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// relativeSource returns the source as recorded in the manifest of the output directory.
+func relativeSource(t *testing.T, outputDir string, source string) string {
+	rel, err := filepath.Rel(outputDir, source)
+	assert.Nil(t, err, "Error resolving source")
+	return filepath.ToSlash(rel)
+}
+
+func Test_ShouldLoadEmptyManifestIfMissing(t *testing.T) {
+	assert := assert.New(t)
+
+	manifest, err := LoadManifest(filepath.Join(t.TempDir(), "missing"))
+	assert.Nil(err, "Error loading manifest")
+	assert.Empty(manifest.Files, "Manifest should be empty")
+}
+
+func Test_ShouldWriteAndLoadManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	manifest, err := LoadManifest(dir)
+	assert.Nil(err, "Error loading manifest")
+	manifest.UseSettings("v1", "settings")
+	err = manifest.Add(NewFile(filepath.Join(dir, "src/script.sh"), filepath.Join(dir, "src/script-sh.adoc"), "= script.sh\n"), "hash")
+	assert.Nil(err, "Error adding file")
+	err = manifest.Write()
+	assert.Nil(err, "Error writing manifest")
+
+	loaded, err := LoadManifest(dir)
+	assert.Nil(err, "Error loading manifest")
+	assert.Equal("v1", loaded.Version, "Incorrect version")
+	assert.Equal("settings", loaded.Settings, "Incorrect settings")
+	assert.Equal(map[string]ManifestEntry{"src/script-sh.adoc": {Source: "src/script.sh", Hash: "hash"}}, loaded.Files, "Incorrect files")
+}
+
+func Test_ShouldFailToLoadInvalidManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFile(t, filepath.Join(dir, ManifestFilename), "not json")
+
+	_, err := LoadManifest(dir)
+	assert.NotNil(err, "Invalid manifest should fail")
+}
+
+func Test_ShouldPruneFilesWithoutSource(t *testing.T) {
+	assert := assert.New(t)
+
+	srcDir := t.TempDir()
+	outputDir := t.TempDir()
+	createFile(t, filepath.Join(srcDir, "kept.sh"), "#!/bin/bash\n")
+	createFile(t, filepath.Join(outputDir, "src/kept-sh.adoc"), "= kept.sh\n")
+	createFile(t, filepath.Join(outputDir, "src/old/deleted-sh.adoc"), "= deleted.sh\n")
+	createFile(t, filepath.Join(outputDir, "src/hand-written.adoc"), "= Hand-written page\n")
+
+	manifest, err := LoadManifest(outputDir)
+	assert.Nil(err, "Error loading manifest")
+	files := []*File{
+		NewFile(filepath.Join(srcDir, "kept.sh"), filepath.Join(outputDir, "src/kept-sh.adoc"), ""),
+		NewFile(filepath.Join(srcDir, "old/deleted.sh"), filepath.Join(outputDir, "src/old/deleted-sh.adoc"), ""),
+		NewFile(filepath.Join(srcDir, "removed.sh"), filepath.Join(outputDir, "src/removed-sh.adoc"), ""),
+	}
+	for _, file := range files {
+		err = manifest.Add(file, "")
+		assert.Nil(err, "Error adding file")
+	}
+
+	assert.Equal([]string{filepath.Join(outputDir, "src/old/deleted-sh.adoc")}, manifest.Orphans(), "Incorrect orphans")
+
+	err = manifest.Prune()
+	assert.Nil(err, "Error pruning files")
+	assert.FileExists(filepath.Join(outputDir, "src/kept-sh.adoc"), "File with existing source should be kept")
+	assert.FileExists(filepath.Join(outputDir, "src/hand-written.adoc"), "Hand-written file should be kept")
+	assert.NoFileExists(filepath.Join(outputDir, "src/old/deleted-sh.adoc"), "Orphaned file should be deleted")
+	assert.NoDirExists(filepath.Join(outputDir, "src/old"), "Empty directory should be deleted")
+	assert.Equal(map[string]ManifestEntry{"src/kept-sh.adoc": {Source: relativeSource(t, outputDir, filepath.Join(srcDir, "kept.sh"))}}, manifest.Files, "Pruned files should be removed from manifest")
+
+	_, err = os.Stat(outputDir)
+	assert.Nil(err, "Output directory should be kept")
+}
+
+func Test_ShouldPruneFilesOfMatchingSources(t *testing.T) {
+	assert := assert.New(t)
+
+	srcDir := t.TempDir()
+	outputDir := t.TempDir()
+	createFile(t, filepath.Join(srcDir, "kept.sh"), "#!/bin/bash\n")
+	createFile(t, filepath.Join(srcDir, "excluded.sh"), "#!/bin/bash\n")
+	createFile(t, filepath.Join(outputDir, "src/kept-sh.adoc"), "= kept.sh\n")
+	createFile(t, filepath.Join(outputDir, "src/excluded-sh.adoc"), "= excluded.sh\n")
+
+	manifest, err := LoadManifest(outputDir)
+	assert.Nil(err, "Error loading manifest")
+	err = manifest.Add(NewFile(filepath.Join(srcDir, "kept.sh"), filepath.Join(outputDir, "src/kept-sh.adoc"), ""), "")
+	assert.Nil(err, "Error adding file")
+	err = manifest.Add(NewFile(filepath.Join(srcDir, "excluded.sh"), filepath.Join(outputDir, "src/excluded-sh.adoc"), ""), "")
+	assert.Nil(err, "Error adding file")
+
+	err = manifest.PruneSources(func(source string) bool {
+		return source == filepath.Join(srcDir, "excluded.sh")
+	})
+	assert.Nil(err, "Error pruning files")
+	assert.FileExists(filepath.Join(outputDir, "src/kept-sh.adoc"), "File of other source should be kept")
+	assert.NoFileExists(filepath.Join(outputDir, "src/excluded-sh.adoc"), "File of matching source should be deleted")
+	assert.Equal(map[string]ManifestEntry{"src/kept-sh.adoc": {Source: relativeSource(t, outputDir, filepath.Join(srcDir, "kept.sh"))}}, manifest.Files, "Pruned files should be removed from manifest")
+}
+
+func Test_ShouldResolveSourcesFromOtherWorkingDir(t *testing.T) {
+	assert := assert.New(t)
+
+	wd, err := os.Getwd()
+	assert.Nil(err, "Error getting working directory")
+	t.Cleanup(func() {
+		err := os.Chdir(wd)
+		assert.Nil(err, "Error restoring working directory")
+	})
+
+	root := t.TempDir()
+	createFile(t, filepath.Join(root, "repo/src/run.sh"), "#!/bin/bash\n")
+	createFile(t, filepath.Join(root, "repo/docs/src/run-sh.adoc"), "= run.sh\n")
+	err = os.Chdir(filepath.Join(root, "repo"))
+	assert.Nil(err, "Error changing working directory")
+	manifest, err := LoadManifest("docs")
+	assert.Nil(err, "Error loading manifest")
+	err = manifest.Add(NewFile("src/run.sh", "docs/src/run-sh.adoc", ""), "hash")
+	assert.Nil(err, "Error adding file")
+	err = manifest.Write()
+	assert.Nil(err, "Error writing manifest")
+	assert.Equal(map[string]ManifestEntry{"src/run-sh.adoc": {Source: "../src/run.sh", Hash: "hash"}}, manifest.Files, "Source should be relative to the output directory")
+
+	err = os.Chdir(root)
+	assert.Nil(err, "Error changing working directory")
+	manifest, err = LoadManifest("repo/docs")
+	assert.Nil(err, "Error loading manifest")
+	assert.True(manifest.IsUnchanged("repo/docs/src/run-sh.adoc", "repo/src/run.sh", "hash"), "File should be unchanged")
+	assert.Empty(manifest.Orphans(), "File should not be orphaned")
+	err = manifest.Prune()
+	assert.Nil(err, "Error pruning files")
+	assert.FileExists("repo/docs/src/run-sh.adoc", "File with existing source should be kept")
+}
+
+func Test_ShouldTrackChangesOfManifest(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	manifest, err := LoadManifest(dir)
+	assert.Nil(err, "Error loading manifest")
+	manifest.UseSettings("v1", "settings")
+	err = manifest.Add(NewFile("run.sh", filepath.Join(dir, "run-sh.adoc"), ""), "hash")
+	assert.Nil(err, "Error adding file")
+	assert.True(manifest.Changed(), "New manifest should be changed")
+	err = manifest.Write()
+	assert.Nil(err, "Error writing manifest")
+
+	manifest, err = LoadManifest(dir)
+	assert.Nil(err, "Error loading manifest")
+	manifest.UseSettings("v1", "settings")
+	err = manifest.Add(NewFile("run.sh", filepath.Join(dir, "run-sh.adoc"), ""), "hash")
+	assert.Nil(err, "Error adding file")
+	assert.False(manifest.Changed(), "Same settings and files should not change the manifest")
+
+	err = manifest.Add(NewFile("run.sh", filepath.Join(dir, "run-sh.adoc"), ""), "other")
+	assert.Nil(err, "Error adding file")
+	assert.True(manifest.Changed(), "New hash should change the manifest")
+}
+
+func Test_ShouldDetectUnchangedFiles(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "src/script-sh.adoc")
+	missingPath := filepath.Join(dir, "src/missing-sh.adoc")
+	createFile(t, path, "= script.sh\n")
+
+	manifest, err := LoadManifest(dir)
+	assert.Nil(err, "Error loading manifest")
+	manifest.UseSettings("v1", "settings")
+	err = manifest.Add(NewFile("src/script.sh", path, ""), "hash")
+	assert.Nil(err, "Error adding file")
+	err = manifest.Add(NewFile("src/missing.sh", missingPath, ""), "hash")
+	assert.Nil(err, "Error adding file")
+
+	tests := []struct {
+		path     string
+		source   string
+		hash     string
+		expected bool
+	}{
+		{path: path, source: "src/script.sh", hash: "hash", expected: true},
+		{path: path, source: "src/script.sh", hash: "other", expected: false},
+		{path: path, source: "src/other.sh", hash: "hash", expected: false},
+		{path: path, source: "src/script.sh", hash: "", expected: false},
+		{path: missingPath, source: "src/missing.sh", hash: "hash", expected: false},
+		{path: filepath.Join(dir, "unknown.adoc"), source: "unknown.sh", hash: "hash", expected: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, manifest.IsUnchanged(test.path, test.source, test.hash), "Incorrect result for "+test.path+" "+test.hash)
+	}
+
+	manifest.UseSettings("v1", "settings")
+	assert.True(manifest.IsUnchanged(path, "src/script.sh", "hash"), "Same settings should keep the hashes")
+	manifest.UseSettings("v2", "settings")
+	assert.False(manifest.IsUnchanged(path, "src/script.sh", "hash"), "New version should discard the hashes")
+}
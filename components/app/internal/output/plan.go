@@ -0,0 +1,132 @@
+package output
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Status describes how a generated File differs from the file system.
+type Status string
+
+const (
+	// StatusMissing marks files which do not exist in the file system yet.
+	StatusMissing Status = "missing"
+
+	// StatusStale marks files whose content in the file system differs from the generated content.
+	StatusStale Status = "stale"
+
+	// StatusUpToDate marks files whose content in the file system equals the generated content.
+	StatusUpToDate Status = "up-to-date"
+
+	// StatusOrphaned marks files in the file system which are not generated anymore.
+	StatusOrphaned Status = "orphaned"
+)
+
+// Change represents the comparison of a single File with the file system. Orphaned files have no
+// generated content.
+type Change struct {
+	File    *File
+	Status  Status
+	Current string
+}
+
+// Plan compares all generated Files with the file system without writing anything.
+type Plan struct {
+	Changes []Change
+}
+
+// NewPlan compares the generated files with their counterparts in the file system.
+func NewPlan(files []*File) (*Plan, error) {
+	plan := &Plan{Changes: []Change{}}
+	for _, file := range files {
+		current, err := os.ReadFile(file.Path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			plan.Changes = append(plan.Changes, Change{File: file, Status: StatusMissing})
+		case err != nil:
+			return nil, fmt.Errorf("failed to read file: %v", err)
+		case string(current) == file.Content:
+			plan.Changes = append(plan.Changes, Change{File: file, Status: StatusUpToDate, Current: file.Content})
+		default:
+			plan.Changes = append(plan.Changes, Change{File: file, Status: StatusStale, Current: string(current)})
+		}
+	}
+	return plan, nil
+}
+
+// AddOrphans marks the given files as orphaned (see Manifest.Orphans). Files which are part of
+// the Plan already are skipped.
+func (plan *Plan) AddOrphans(paths []string) {
+	known := map[string]bool{}
+	for _, change := range plan.Changes {
+		known[change.File.Path] = true
+	}
+
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		if !known[path] {
+			plan.Changes = append(plan.Changes, Change{File: &File{Path: path}, Status: StatusOrphaned})
+			known[path] = true
+		}
+	}
+}
+
+// OutOfDate returns all changes which are not up to date, sorted by path.
+func (plan *Plan) OutOfDate() []Change {
+	changes := []Change{}
+	for _, change := range plan.Changes {
+		if change.Status != StatusUpToDate {
+			changes = append(changes, change)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].File.Path < changes[j].File.Path
+	})
+	return changes
+}
+
+// IsUpToDate returns true if all generated files exist in the file system with the same content
+// and there are no orphaned files.
+func (plan *Plan) IsUpToDate() bool {
+	return len(plan.OutOfDate()) == 0
+}
+
+// dryRunActions describes what happens to a file with the given Status when the documentation is
+// written. Orphaned files are only part of the Plan if they are pruned.
+var dryRunActions = map[Status]string{
+	StatusMissing:  "create",
+	StatusStale:    "update",
+	StatusUpToDate: "unchanged",
+	StatusOrphaned: "delete",
+}
+
+// WriteDryRunReport writes a report of what would happen to each file when the documentation is
+// written, followed by a summary.
+func (plan *Plan) WriteDryRunReport(writer io.Writer) {
+	counts := map[Status]int{}
+	for _, change := range plan.Changes {
+		counts[change.Status]++
+		if change.Status == StatusOrphaned {
+			fmt.Fprintf(writer, "%-10s %s\n", dryRunActions[change.Status], change.File.Path)
+			continue
+		}
+		fmt.Fprintf(writer, "%-10s %s    ==>    %s\n", dryRunActions[change.Status], change.File.Source, change.File.Path)
+	}
+	fmt.Fprintf(writer, "%d to create, %d to update, %d to delete, %d unchanged\n",
+		counts[StatusMissing], counts[StatusStale], counts[StatusOrphaned], counts[StatusUpToDate])
+}
+
+// WriteCheckReport writes a report of all files which are not up to date. Stale files are shown
+// as unified diff.
+func (plan *Plan) WriteCheckReport(writer io.Writer) {
+	for _, change := range plan.OutOfDate() {
+		fmt.Fprintf(writer, "%-10s %s\n", change.Status, change.File.Path)
+		if change.Status == StatusStale {
+			fmt.Fprint(writer, Diff(change.File.Path, change.Current, change.File.Content))
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package output
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createFile(t *testing.T, path string, content string) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	assert.Nil(t, err, "Error creating directory")
+	err = os.WriteFile(path, []byte(content), 0644)
+	assert.Nil(t, err, "Error creating file")
+}
+
+func Test_ShouldCompareFilesWithFileSystem(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFile(t, filepath.Join(dir, "src/unchanged-sh.adoc"), "= unchanged.sh\n")
+	createFile(t, filepath.Join(dir, "src/stale-sh.adoc"), "= stale.sh\nold\n")
+	createFile(t, filepath.Join(dir, "src/deleted-sh.adoc"), "= deleted.sh\n")
+	createFile(t, filepath.Join(dir, "src/notes.txt"), "not generated")
+	createFile(t, filepath.Join(dir, "index.adoc"), "= Hand-written page\n")
+
+	files := []*File{
+		NewFile("src/unchanged.sh", filepath.Join(dir, "src/unchanged-sh.adoc"), "= unchanged.sh\n"),
+		NewFile("src/stale.sh", filepath.Join(dir, "src/stale-sh.adoc"), "= stale.sh\nnew\n"),
+		NewFile("src/new.sh", filepath.Join(dir, "src/new-sh.adoc"), "= new.sh\n"),
+	}
+
+	plan, err := NewPlan(files)
+	assert.Nil(err, "Error creating plan")
+	plan.AddOrphans([]string{filepath.Join(dir, "src/deleted-sh.adoc"), filepath.Join(dir, "src/stale-sh.adoc")})
+
+	expected := map[string]Status{
+		filepath.Join(dir, "src/deleted-sh.adoc"): StatusOrphaned,
+		filepath.Join(dir, "src/new-sh.adoc"):     StatusMissing,
+		filepath.Join(dir, "src/stale-sh.adoc"):   StatusStale,
+	}
+	outOfDate := plan.OutOfDate()
+	assert.Len(outOfDate, len(expected), "Incorrect number of changes")
+	for _, change := range outOfDate {
+		assert.Equal(expected[change.File.Path], change.Status, "Incorrect status for "+change.File.Path)
+	}
+	assert.False(plan.IsUpToDate(), "Plan should not be up to date")
+
+	report := &bytes.Buffer{}
+	plan.WriteCheckReport(report)
+	assert.Contains(report.String(), "stale      "+filepath.Join(dir, "src/stale-sh.adoc"), "Stale file should be reported")
+	assert.Contains(report.String(), "-old\n+new\n", "Diff of stale file should be reported")
+	assert.Contains(report.String(), "orphaned   "+filepath.Join(dir, "src/deleted-sh.adoc"), "Orphaned file should be reported")
+	assert.NotContains(report.String(), "unchanged-sh.adoc", "Up to date files should not be reported")
+}
+
+func Test_ShouldWriteDryRunReport(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFile(t, filepath.Join(dir, "unchanged-sh.adoc"), "= unchanged.sh\n")
+	createFile(t, filepath.Join(dir, "stale-sh.adoc"), "= stale.sh\nold\n")
+	createFile(t, filepath.Join(dir, "deleted-sh.adoc"), "= deleted.sh\n")
+
+	plan, err := NewPlan([]*File{
+		NewFile("new.sh", filepath.Join(dir, "new-sh.adoc"), "= new.sh\n"),
+		NewFile("stale.sh", filepath.Join(dir, "stale-sh.adoc"), "= stale.sh\nnew\n"),
+		NewFile("unchanged.sh", filepath.Join(dir, "unchanged-sh.adoc"), "= unchanged.sh\n"),
+	})
+	assert.Nil(err, "Error creating plan")
+	plan.AddOrphans([]string{filepath.Join(dir, "deleted-sh.adoc")})
+
+	report := &bytes.Buffer{}
+	plan.WriteDryRunReport(report)
+
+	expected := "create     new.sh    ==>    " + filepath.Join(dir, "new-sh.adoc") + "\n" +
+		"update     stale.sh    ==>    " + filepath.Join(dir, "stale-sh.adoc") + "\n" +
+		"unchanged  unchanged.sh    ==>    " + filepath.Join(dir, "unchanged-sh.adoc") + "\n" +
+		"delete     " + filepath.Join(dir, "deleted-sh.adoc") + "\n" +
+		"1 to create, 1 to update, 1 to delete, 1 unchanged\n"
+	assert.Equal(expected, report.String(), "Incorrect dry-run report")
+}
+
+func Test_ShouldBeUpToDate(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	createFile(t, filepath.Join(dir, "script-sh.adoc"), "= script.sh\n")
+
+	plan, err := NewPlan([]*File{NewFile("script.sh", filepath.Join(dir, "script-sh.adoc"), "= script.sh\n")})
+	assert.Nil(err, "Error creating plan")
+	plan.AddOrphans([]string{})
+	assert.True(plan.IsUpToDate(), "Plan should be up to date")
+}
@@ -0,0 +1,90 @@
+package pipeline
+
+// result holds the outcome of processing a single item. The done channel is closed as soon as
+// the item is processed.
+type result[R any] struct {
+	value R
+	err   error
+	done  chan struct{}
+}
+
+// task is a single item passed to the workers along with the result slot to fill.
+type task[T any, R any] struct {
+	item   T
+	result *result[R]
+}
+
+// Items returns a closed channel which holds all given items. Use it to process items which are
+// already known up front.
+func Items[T any](items []T) <-chan T {
+	ch := make(chan T, len(items))
+	for _, item := range items {
+		ch <- item
+	}
+	close(ch)
+	return ch
+}
+
+// Process runs the work function for all items received from the items channel using the given
+// number of concurrent workers (at least one). Items are processed as soon as they are received,
+// so producing the items overlaps with processing them. The results are passed to the emit
+// function in the order the items were received as soon as they are available, so the output is
+// deterministic regardless of the order in which the workers finish. The emit function is never
+// called concurrently. Processing stops at the first error of either function and this error is
+// returned. Items received after an error are discarded, so the producer is never blocked.
+func Process[T any, R any](items <-chan T, jobs int, work func(T) (R, error), emit func(R) error) error {
+	jobs = max(jobs, 1)
+	tasks := make(chan task[T, R])
+	pending := make(chan *result[R], jobs)
+	quit := make(chan struct{})
+	defer close(quit)
+	go dispatch(items, tasks, pending, quit)
+	for range jobs {
+		go func() {
+			for task := range tasks {
+				task.result.value, task.result.err = work(task.item)
+				close(task.result.done)
+			}
+		}()
+	}
+
+	for result := range pending {
+		<-result.done
+		if result.err != nil {
+			return result.err
+		}
+		err := emit(result.value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dispatch passes all received items to the workers and queues their result slots in the same
+// order. Dispatching stops early if the quit channel is closed. The tasks and pending channels
+// are closed afterwards, so the workers stop. Remaining items are drained last.
+func dispatch[T any, R any](items <-chan T, tasks chan<- task[T, R], pending chan<- *result[R], quit <-chan struct{}) {
+	defer drain(items)
+	defer close(tasks)
+	defer close(pending)
+	for item := range items {
+		result := &result[R]{done: make(chan struct{})}
+		select {
+		case pending <- result:
+		case <-quit:
+			return
+		}
+		select {
+		case tasks <- task[T, R]{item: item, result: result}:
+		case <-quit:
+			return
+		}
+	}
+}
+
+// drain discards all remaining items until the channel is closed.
+func drain[T any](items <-chan T) {
+	for range items {
+	}
+}
@@ -0,0 +1,141 @@
+package pipeline
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldEmitResultsInOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	items := []int{5, 1, 4, 2, 3, 0}
+	for _, jobs := range []int{0, 1, 2, len(items), 10} {
+		emitted := []string{}
+		err := Process(Items(items), jobs, func(item int) (string, error) {
+			time.Sleep(time.Duration(item) * time.Millisecond)
+			return fmt.Sprint(item), nil
+		}, func(value string) error {
+			emitted = append(emitted, value)
+			return nil
+		})
+
+		assert.Nil(err, "Error processing items")
+		assert.Equal([]string{"5", "1", "4", "2", "3", "0"}, emitted, fmt.Sprintf("Incorrect order with %d jobs", jobs))
+	}
+}
+
+func Test_ShouldLimitConcurrentWorkers(t *testing.T) {
+	assert := assert.New(t)
+
+	var running, maxRunning atomic.Int32
+	err := Process(Items(make([]int, 20)), 3, func(item int) (int, error) {
+		current := running.Add(1)
+		for {
+			seen := maxRunning.Load()
+			if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		running.Add(-1)
+		return item, nil
+	}, func(value int) error {
+		return nil
+	})
+
+	assert.Nil(err, "Error processing items")
+	assert.LessOrEqual(maxRunning.Load(), int32(3), "Too many concurrent workers")
+}
+
+func Test_ShouldStopAtFirstError(t *testing.T) {
+	assert := assert.New(t)
+
+	var processed atomic.Int32
+	emitted := 0
+	items := []int{}
+	for i := range 1000 {
+		items = append(items, i)
+	}
+	err := Process(Items(items), 2, func(item int) (int, error) {
+		processed.Add(1)
+		time.Sleep(time.Millisecond)
+		if item == 2 {
+			return 0, fmt.Errorf("failed")
+		}
+		return item, nil
+	}, func(value int) error {
+		emitted++
+		return nil
+	})
+
+	assert.NotNil(err, "Error should be returned")
+	assert.Equal(2, emitted, "Results after the error should not be emitted")
+	assert.Less(processed.Load(), int32(1000), "Processing should stop early")
+}
+
+func Test_ShouldStopAtEmitError(t *testing.T) {
+	assert := assert.New(t)
+
+	emitted := 0
+	err := Process(Items([]int{1, 2, 3}), 2, func(item int) (int, error) {
+		return item, nil
+	}, func(value int) error {
+		emitted++
+		return fmt.Errorf("failed to emit %d", value)
+	})
+
+	assert.EqualError(err, "failed to emit 1", "Incorrect error")
+	assert.Equal(1, emitted, "Emitting should stop at the first error")
+}
+
+func Test_ShouldProcessItemsBeforeChannelIsClosed(t *testing.T) {
+	assert := assert.New(t)
+
+	items := make(chan int)
+	emitted := make(chan int)
+	go func() {
+		defer close(items)
+		for i := range 3 {
+			items <- i
+			assert.Equal(i, <-emitted, "Item should be emitted before the next one is sent")
+		}
+	}()
+	err := Process(items, 2, func(item int) (int, error) {
+		return item, nil
+	}, func(value int) error {
+		emitted <- value
+		return nil
+	})
+
+	assert.Nil(err, "Error processing items")
+}
+
+func Test_ShouldNotBlockProducerAfterError(t *testing.T) {
+	assert := assert.New(t)
+
+	items := make(chan int)
+	produced := make(chan struct{})
+	go func() {
+		defer close(produced)
+		defer close(items)
+		for i := range 100 {
+			items <- i
+		}
+	}()
+	err := Process(items, 2, func(item int) (int, error) {
+		return 0, fmt.Errorf("failed")
+	}, func(value int) error {
+		return nil
+	})
+
+	assert.NotNil(err, "Error should be returned")
+	select {
+	case <-produced:
+	case <-time.After(time.Second):
+		assert.Fail("Producer should not be blocked")
+	}
+}
@@ -0,0 +1,228 @@
+package preview
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern    = regexp.MustCompile(`^(={1,6}) (.+)$`)
+	blockTitlePattern = regexp.MustCompile(`^\.[^.\s]`)
+	attributePattern  = regexp.MustCompile(`^\[.*\]$`)
+	listItemPattern   = regexp.MustCompile(`^(\*|-|\.)\s+(.*)$`)
+
+	urlPattern    = regexp.MustCompile(`(^|\s)(https?://[^\s\[<]*[^\s\[<.,;:!?)])`)
+	linkPattern   = regexp.MustCompile(`link:(\S+?)\[([^\]]*)\]`)
+	xrefPattern   = regexp.MustCompile(`xref:(\S+?)\[([^\]]*)\]`)
+	monoPattern   = regexp.MustCompile("`([^`]+)`")
+	strongPattern = regexp.MustCompile(`(^|[^\w*])\*([^*\s](?:[^*]*[^*\s])?)\*($|[^\w*])`)
+)
+
+// ToHTML converts AsciiDoc into HTML. Only the subset of AsciiDoc written by the app and common
+// markup from the inline comments is supported: headings, block titles, tables, lists, literal
+// blocks, paragraphs, links, xrefs, bold and monospace text. Xrefs point to the page paths of the
+// Site. Links to other than http, https or relative targets are rendered as plain text, just like
+// everything else.
+func ToHTML(asciidoc string) string {
+	conv := &converter{lines: strings.Split(asciidoc, "\n")}
+	for conv.pos < len(conv.lines) {
+		conv.block()
+	}
+	return conv.html.String()
+}
+
+// converter converts AsciiDoc line by line. Each block consumes its lines.
+type converter struct {
+	lines []string
+	pos   int
+	title string
+	html  strings.Builder
+}
+
+// block converts the block starting at the current line.
+func (conv *converter) block() {
+	line := conv.lines[conv.pos]
+	switch {
+	case strings.TrimSpace(line) == "" || strings.HasPrefix(line, "//") || attributePattern.MatchString(line):
+		conv.pos++
+	case blockTitlePattern.MatchString(line):
+		conv.title = line[1:]
+		conv.pos++
+	case headingPattern.MatchString(line):
+		conv.heading(line)
+	case line == "|===":
+		conv.table()
+	case line == "...." || line == "----":
+		conv.literal(line)
+	case listItemPattern.MatchString(line):
+		conv.list()
+	default:
+		conv.paragraph()
+	}
+}
+
+// startsBlock returns true if the line starts a block which interrupts a paragraph.
+func startsBlock(line string) bool {
+	return strings.TrimSpace(line) == "" || line == "|===" || line == "...." || line == "----" ||
+		headingPattern.MatchString(line) || listItemPattern.MatchString(line)
+}
+
+// writeTitle writes the pending block title (if any).
+func (conv *converter) writeTitle() {
+	if conv.title != "" {
+		conv.html.WriteString(`<div class="title">` + inline(conv.title) + "</div>\n")
+		conv.title = ""
+	}
+}
+
+// heading converts a heading like `== text`. The title of the page (`= text`) is a `h1`.
+func (conv *converter) heading(line string) {
+	matches := headingPattern.FindStringSubmatch(line)
+	level := len(matches[1])
+	fmt.Fprintf(&conv.html, "<h%d>%s</h%d>\n", level, inline(matches[2]), level)
+	conv.pos++
+}
+
+// table converts a table using the `|===` syntax. A first row followed by an empty line is the
+// header of the table.
+func (conv *converter) table() {
+	rows := [][]string{}
+	header := false
+	for conv.pos++; conv.pos < len(conv.lines) && conv.lines[conv.pos] != "|==="; conv.pos++ {
+		line := conv.lines[conv.pos]
+		if strings.TrimSpace(line) == "" {
+			header = header || len(rows) == 1
+			continue
+		}
+		rows = append(rows, splitCells(line))
+	}
+	conv.pos++
+
+	conv.writeTitle()
+	conv.html.WriteString("<table>\n")
+	for i, row := range rows {
+		tag := "td"
+		if header && i == 0 {
+			tag = "th"
+		}
+		conv.html.WriteString("<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(&conv.html, "<%s>%s</%s>", tag, inline(cell), tag)
+		}
+		conv.html.WriteString("</tr>\n")
+	}
+	conv.html.WriteString("</table>\n")
+}
+
+// splitCells splits a table row like `|a |b` into its cells. Escaped pipes (`\|`) are part of
+// the cell.
+func splitCells(line string) []string {
+	cells := []string{}
+	cell := ""
+	for i := 0; i < len(line); i++ {
+		switch {
+		case strings.HasPrefix(line[i:], `\|`):
+			cell += "|"
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell))
+			cell = ""
+		default:
+			cell += string(line[i])
+		}
+	}
+	cells = append(cells, strings.TrimSpace(cell))
+	return cells[1:]
+}
+
+// literal converts a literal block (`....`) or a listing block (`----`) into preformatted text.
+func (conv *converter) literal(delimiter string) {
+	content := []string{}
+	for conv.pos++; conv.pos < len(conv.lines) && conv.lines[conv.pos] != delimiter; conv.pos++ {
+		content = append(content, conv.lines[conv.pos])
+	}
+	conv.pos++
+
+	conv.writeTitle()
+	conv.html.WriteString("<pre>" + html.EscapeString(strings.Join(content, "\n")) + "</pre>\n")
+}
+
+// list converts consecutive list items into an unordered list (`*` or `-`) or an ordered list
+// (`.`).
+func (conv *converter) list() {
+	tag := "ul"
+	if listItemPattern.FindStringSubmatch(conv.lines[conv.pos])[1] == "." {
+		tag = "ol"
+	}
+
+	conv.writeTitle()
+	conv.html.WriteString("<" + tag + ">\n")
+	for ; conv.pos < len(conv.lines) && listItemPattern.MatchString(conv.lines[conv.pos]); conv.pos++ {
+		item := listItemPattern.FindStringSubmatch(conv.lines[conv.pos])[2]
+		conv.html.WriteString("<li>" + inline(item) + "</li>\n")
+	}
+	conv.html.WriteString("</" + tag + ">\n")
+}
+
+// paragraph converts all lines up to the next block into a paragraph.
+func (conv *converter) paragraph() {
+	lines := []string{conv.lines[conv.pos]}
+	for conv.pos++; conv.pos < len(conv.lines) && !startsBlock(conv.lines[conv.pos]); conv.pos++ {
+		lines = append(lines, conv.lines[conv.pos])
+	}
+
+	conv.writeTitle()
+	conv.html.WriteString("<p>" + inline(strings.Join(lines, "\n")) + "</p>\n")
+}
+
+// inline escapes the text and converts the inline markup. Xrefs point to the pages of the site,
+// which are served relative to its root. Absolute xrefs (e.g. from an absolute source dir) are
+// joined with the root as well, so they never become protocol-relative URLs.
+func inline(text string) string {
+	text = html.EscapeString(text)
+	text = urlPattern.ReplaceAllString(text, `$1<a href="$2">$2</a>`)
+	text = linkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		matches := linkPattern.FindStringSubmatch(link)
+		return anchor(matches[1], matches[2])
+	})
+	text = xrefPattern.ReplaceAllStringFunc(text, func(xref string) string {
+		matches := xrefPattern.FindStringSubmatch(xref)
+		return anchor(path.Join("/", matches[1]), matches[2])
+	})
+	text = monoPattern.ReplaceAllString(text, "<code>$1</code>")
+	return strongPattern.ReplaceAllString(text, "$1<strong>$2</strong>$3")
+}
+
+// anchor returns a link to the target. The target and the text are already escaped by inline.
+// Links without text show the target. Unsafe targets (see isSafeTarget) are rendered as text only.
+func anchor(target string, text string) string {
+	if text == "" {
+		text = target
+	}
+	raw := html.UnescapeString(target)
+	if !isSafeTarget(raw) {
+		return text
+	}
+	return `<a href="` + html.EscapeString(raw) + `">` + text + "</a>"
+}
+
+// isSafeTarget returns true if the link target is an http or https URL or a path on the same host.
+// Other schemes (e.g. `javascript:`) and protocol-relative URLs (e.g. `//host`) are unsafe.
+// Backslashes are unsafe as well, because browsers treat them like slashes.
+func isSafeTarget(target string) bool {
+	if strings.Contains(target, "\\") {
+		return false
+	}
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	if parsed.Scheme == "" {
+		return parsed.Host == "" && !strings.HasPrefix(target, "//")
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
@@ -0,0 +1,80 @@
+package preview
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldConvertAsciiDocToHTML(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		asciidoc string
+		expected string
+	}{
+		{asciidoc: "= script.sh\n", expected: "<h1>script.sh</h1>\n"},
+		{asciidoc: "== greet\n", expected: "<h2>greet</h2>\n"},
+		{
+			asciidoc: "Lorem ipsum\ndolor sit amet.\n\nSecond paragraph.\n",
+			expected: "<p>Lorem ipsum\ndolor sit amet.</p>\n<p>Second paragraph.</p>\n",
+		},
+		{
+			asciidoc: "[cols=\"1,5\"]\n|===\n|Language |sh\n|Path |src/script.sh\n|===\n",
+			expected: "<table>\n<tr><td>Language</td><td>sh</td></tr>\n<tr><td>Path</td><td>src/script.sh</td></tr>\n</table>\n",
+		},
+		{
+			asciidoc: ".Arguments\n|===\n|Name |Description\n\n|$1 |Value with \\| pipe\n|===\n",
+			expected: "<div class=\"title\">Arguments</div>\n<table>\n<tr><th>Name</th><th>Description</th></tr>\n<tr><td>$1</td><td>Value with | pipe</td></tr>\n</table>\n",
+		},
+		{
+			asciidoc: "|===\n|all | |Print a greeting.\n|===\n",
+			expected: "<table>\n<tr><td>all</td><td></td><td>Print a greeting.</td></tr>\n</table>\n",
+		},
+		{
+			asciidoc: ".See also\n* xref:lib/util-sh.adoc[util.sh]\n* link:https://sommerfeld.io[Website]\n",
+			expected: "<div class=\"title\">See also</div>\n<ul>\n<li><a href=\"/lib/util-sh.adoc\">util.sh</a></li>\n<li><a href=\"https://sommerfeld.io\">Website</a></li>\n</ul>\n",
+		},
+		{asciidoc: ". First\n. Second\n", expected: "<ol>\n<li>First</li>\n<li>Second</li>\n</ol>\n"},
+		{
+			asciidoc: "[source, bash]\n....\nif [ 1 < 2 ]; then\n  echo \"*\"\nfi\n....\n",
+			expected: "<pre>if [ 1 &lt; 2 ]; then\n  echo &#34;*&#34;\nfi</pre>\n",
+		},
+		{
+			asciidoc: "Use `make <target>` for *all* targets, see https://sommerfeld.io.\n",
+			expected: "<p>Use <code>make &lt;target&gt;</code> for <strong>all</strong> targets, see <a href=\"https://sommerfeld.io\">https://sommerfeld.io</a>.</p>\n",
+		},
+		{asciidoc: "<script>alert(1)</script>\n", expected: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, ToHTML(test.asciidoc), "Incorrect HTML for "+test.asciidoc)
+	}
+}
+
+func Test_ShouldOnlyLinkSafeTargets(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		asciidoc string
+		expected string
+	}{
+		{asciidoc: "link:https://sommerfeld.io[Website]", expected: `<a href="https://sommerfeld.io">Website</a>`},
+		{asciidoc: "link:https://sommerfeld.io?a=1&b=2[]", expected: `<a href="https://sommerfeld.io?a=1&amp;b=2">https://sommerfeld.io?a=1&amp;b=2</a>`},
+		{asciidoc: "link:docs/page.html[Page]", expected: `<a href="docs/page.html">Page</a>`},
+		{asciidoc: "link:javascript:alert(1)[x]", expected: "x"},
+		{asciidoc: "link:JavaScript:alert(1)[x]", expected: "x"},
+		{asciidoc: "link:data:text/html,evil[x]", expected: "x"},
+		{asciidoc: "link://evil.example[x]", expected: "x"},
+		{asciidoc: "link:/\\evil.example[x]", expected: "x"},
+		{asciidoc: `link:https://sommerfeld.io/"onmouseover="alert(1)[x]`, expected: `<a href="https://sommerfeld.io/&#34;onmouseover=&#34;alert(1)">x</a>`},
+		{asciidoc: "xref:lib/util-sh.adoc[util.sh]", expected: `<a href="/lib/util-sh.adoc">util.sh</a>`},
+		{asciidoc: "xref:/evil.example/page[x]", expected: `<a href="/evil.example/page">x</a>`},
+		{asciidoc: "xref:/abs/src/lib/util-sh.adoc[util.sh]", expected: `<a href="/abs/src/lib/util-sh.adoc">util.sh</a>`},
+		{asciidoc: "xref:/\\evil.example[x]", expected: "x"},
+	}
+
+	for _, test := range tests {
+		assert.Equal("<p>"+test.expected+"</p>\n", ToHTML(test.asciidoc), "Incorrect HTML for "+test.asciidoc)
+	}
+}
@@ -0,0 +1,109 @@
+package preview
+
+import (
+	_ "embed"
+	"fmt"
+	"html"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+)
+
+//go:embed templates/layout.html
+var layoutContent string
+
+// VersionPath is the path which returns the current version of the Site. Pages compare it with
+// their own version to reload themselves after the Site was updated.
+const VersionPath = "/_version"
+
+// Site serves the documentation pages as HTML. The pages are kept in memory and are replaced as a
+// whole by each update. The root path serves an index of all pages, all other paths are the paths
+// of the documentation files.
+type Site struct {
+	mutex   sync.RWMutex
+	layout  *template.Template
+	files   map[string]*output.File
+	version int
+}
+
+// NewSite acts as a constructor for a new and empty Site instance.
+func NewSite() *Site {
+	return &Site{
+		layout: template.Must(template.New("layout").Parse(layoutContent)),
+		files:  map[string]*output.File{},
+	}
+}
+
+// Update replaces all pages of the Site with the given documentation files (in AsciiDoc). Pages
+// which are open in a browser reload themselves.
+func (site *Site) Update(files []*output.File) {
+	site.mutex.Lock()
+	defer site.mutex.Unlock()
+
+	site.files = map[string]*output.File{}
+	for _, file := range files {
+		site.files["/"+strings.TrimPrefix(file.Path, "/")] = file
+	}
+	site.version++
+}
+
+// ServeHTTP serves the index, a single page or the version of the Site.
+func (site *Site) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	site.mutex.RLock()
+	defer site.mutex.RUnlock()
+
+	switch r.URL.Path {
+	case VersionPath:
+		fmt.Fprint(w, strconv.Itoa(site.version))
+	case "/":
+		site.render(w, "Index", site.index())
+	default:
+		file, ok := site.files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		site.render(w, file.Source, ToHTML(file.Content))
+	}
+}
+
+// index returns the list of all pages sorted by path.
+func (site *Site) index() string {
+	paths := []string{}
+	for path := range site.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	content := "<h1>Documentation pages</h1>\n"
+	if len(paths) == 0 {
+		return content + "<p>No code files found.</p>\n"
+	}
+	content += "<ul>\n"
+	for _, path := range paths {
+		content += `<li><a href="` + html.EscapeString(path) + `">` + html.EscapeString(site.files[path].Source) + "</a></li>\n"
+	}
+	return content + "</ul>\n"
+}
+
+// render writes the content into the layout of the Site.
+func (site *Site) render(w http.ResponseWriter, title string, content string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := site.layout.Execute(w, struct {
+		Title   string
+		Content template.HTML
+		Version string
+	}{
+		Title:   title,
+		Content: template.HTML(content),
+		Version: strconv.Itoa(site.version),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
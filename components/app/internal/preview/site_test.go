@@ -0,0 +1,61 @@
+package preview
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sommerfeld-io/source2adoc/internal/output"
+	"github.com/stretchr/testify/assert"
+)
+
+// get requests the path from the Site and returns the status code and the body.
+func get(t *testing.T, site *Site, path string) (int, string) {
+	recorder := httptest.NewRecorder()
+	site.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	body, err := io.ReadAll(recorder.Result().Body)
+	assert.Nil(t, err, "Error reading response")
+	return recorder.Code, string(body)
+}
+
+func Test_ShouldServePages(t *testing.T) {
+	assert := assert.New(t)
+
+	site := NewSite()
+	site.Update([]*output.File{
+		output.NewFile("src/script.sh", "src/script-sh.adoc", "= script.sh\n\nLorem ipsum\n"),
+		output.NewFile("src/Makefile", "src/makefile.adoc", "= Makefile\n"),
+	})
+
+	code, body := get(t, site, "/")
+	assert.Equal(http.StatusOK, code, "Index should be served")
+	assert.Contains(body, `<li><a href="/src/makefile.adoc">src/Makefile</a></li>`+"\n"+`<li><a href="/src/script-sh.adoc">src/script.sh</a></li>`, "Index should list all pages sorted by path")
+
+	code, body = get(t, site, "/src/script-sh.adoc")
+	assert.Equal(http.StatusOK, code, "Page should be served")
+	assert.Contains(body, "<title>src/script.sh | source2adoc preview</title>", "Page should have a title")
+	assert.Contains(body, "<h1>script.sh</h1>\n<p>Lorem ipsum</p>", "Page should be rendered as HTML")
+	assert.Contains(body, `const version = "1";`, "Page should know the version of the site")
+
+	code, _ = get(t, site, "/src/missing-sh.adoc")
+	assert.Equal(http.StatusNotFound, code, "Unknown pages should not be found")
+}
+
+func Test_ShouldIncreaseVersionWithEachUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	site := NewSite()
+	_, version := get(t, site, VersionPath)
+	assert.Equal("0", version, "Incorrect initial version")
+	_, body := get(t, site, "/")
+	assert.Contains(body, "No code files found.", "Empty index should be explained")
+
+	site.Update([]*output.File{output.NewFile("src/script.sh", "src/script-sh.adoc", "= script.sh\n")})
+	site.Update([]*output.File{})
+	_, version = get(t, site, VersionPath)
+	assert.Equal("2", version, "Version should increase with each update")
+
+	code, _ := get(t, site, "/src/script-sh.adoc")
+	assert.Equal(http.StatusNotFound, code, "Pages should be replaced by each update")
+}
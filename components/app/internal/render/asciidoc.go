@@ -0,0 +1,86 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AsciiDoc renders documentation pages in AsciiDoc syntax. This is the default format of the app.
+type AsciiDoc struct{}
+
+// Format returns the name of the output format.
+func (r *AsciiDoc) Format() string {
+	return FormatAsciiDoc
+}
+
+// FileExtension returns the suffix of AsciiDoc files.
+func (r *AsciiDoc) FileExtension() string {
+	return ".adoc"
+}
+
+// Heading renders a heading like `== text`.
+func (r *AsciiDoc) Heading(level int, text string) string {
+	return strings.Repeat("=", level+1) + " " + text + "\n"
+}
+
+// Table renders a table using the `|===` syntax. The header is separated from the rows by an
+// empty line.
+func (r *AsciiDoc) Table(table Table) string {
+	asciidoc := ""
+	if table.Title != "" {
+		asciidoc += "." + table.Title + "\n"
+	}
+	if len(table.Cols) > 0 {
+		cols := make([]string, len(table.Cols))
+		for i, col := range table.Cols {
+			cols[i] = strconv.Itoa(col)
+		}
+		asciidoc += "[cols=\"" + strings.Join(cols, ",") + "\"]\n"
+	}
+	asciidoc += "|===\n"
+	if len(table.Header) > 0 {
+		asciidoc += r.row(table.Header)
+		asciidoc += "\n"
+	}
+	for _, row := range table.Rows {
+		asciidoc += r.row(row)
+	}
+	asciidoc += "|===\n"
+	return asciidoc
+}
+
+func (r *AsciiDoc) row(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = "|" + escapeCell(cell)
+	}
+	return strings.Join(escaped, " ") + "\n"
+}
+
+// List renders an unordered list with the title as block title.
+func (r *AsciiDoc) List(title string, items []string) string {
+	asciidoc := ""
+	if title != "" {
+		asciidoc += "." + title + "\n"
+	}
+	for _, item := range items {
+		asciidoc += "* " + item + "\n"
+	}
+	return asciidoc
+}
+
+// Link renders a link macro like `link:url[text]`.
+func (r *AsciiDoc) Link(url string, text string) string {
+	return "link:" + url + "[" + text + "]"
+}
+
+// Xref renders a cross reference like `xref:page[text]`. Antora resolves the target relative to
+// the pages directory, so the page containing the xref is not relevant.
+func (r *AsciiDoc) Xref(from string, to string, text string) string {
+	return "xref:" + to + "[" + text + "]"
+}
+
+// Comment renders a single line comment like `// text`.
+func (r *AsciiDoc) Comment(text string) string {
+	return "// " + text + "\n"
+}
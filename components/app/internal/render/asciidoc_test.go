@@ -0,0 +1,59 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldRenderAsciiDoc(t *testing.T) {
+	assert := assert.New(t)
+
+	renderer := &AsciiDoc{}
+	assert.Equal(".adoc", renderer.FileExtension(), "Incorrect file extension")
+	assert.Equal("= script.sh\n", renderer.Heading(0, "script.sh"), "Incorrect title")
+	assert.Equal("== greet\n", renderer.Heading(1, "greet"), "Incorrect section heading")
+	assert.Equal("link:https://sommerfeld.io[Website]", renderer.Link("https://sommerfeld.io", "Website"), "Incorrect link")
+	assert.Equal("xref:lib/util-sh.adoc[util.sh]", renderer.Xref("src/script-sh.adoc", "lib/util-sh.adoc", "util.sh"), "Incorrect xref")
+	assert.Equal(".See also\n* a\n* b\n", renderer.List("See also", []string{"a", "b"}), "Incorrect list")
+	assert.Equal("// Lorem ipsum\n", renderer.Comment("Lorem ipsum"), "Incorrect comment")
+}
+
+func Test_ShouldRenderAsciiDocTables(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		table    Table
+		expected string
+	}{
+		{
+			table: Table{Cols: []int{1, 5}, Rows: [][]string{{"Language", "sh"}}},
+			expected: `[cols="1,5"]
+|===
+|Language |sh
+|===
+`,
+		},
+		{
+			table: Table{
+				Title:  "Arguments",
+				Cols:   []int{1, 1, 5},
+				Header: []string{"Name", "Type", "Description"},
+				Rows:   [][]string{{"$1", "int", "Value with | pipe"}},
+			},
+			expected: `.Arguments
+[cols="1,1,5"]
+|===
+|Name |Type |Description
+
+|$1 |int |Value with \| pipe
+|===
+`,
+		},
+	}
+
+	renderer := &AsciiDoc{}
+	for _, test := range tests {
+		assert.Equal(test.expected, renderer.Table(test.table), "Incorrect table")
+	}
+}
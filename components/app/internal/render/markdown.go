@@ -0,0 +1,99 @@
+package render
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Markdown renders documentation pages in GitHub-flavored Markdown (e.g. for MkDocs).
+type Markdown struct{}
+
+// Format returns the name of the output format.
+func (r *Markdown) Format() string {
+	return FormatMarkdown
+}
+
+// FileExtension returns the suffix of Markdown files.
+func (r *Markdown) FileExtension() string {
+	return ".md"
+}
+
+// Heading renders a heading like `## text`.
+func (r *Markdown) Heading(level int, text string) string {
+	return strings.Repeat("#", level+1) + " " + text + "\n"
+}
+
+// Table renders a GitHub-flavored table. The title is rendered as bold paragraph. Markdown
+// tables always need a header row, so tables without header get an empty one. The width of the
+// columns is not supported.
+func (r *Markdown) Table(table Table) string {
+	markdown := ""
+	if table.Title != "" {
+		markdown += "**" + table.Title + "**\n\n"
+	}
+
+	header := table.Header
+	if len(header) == 0 {
+		header = make([]string, r.columns(table))
+	}
+	markdown += r.row(header)
+	markdown += "|" + strings.Repeat(" --- |", len(header)) + "\n"
+	for _, row := range table.Rows {
+		markdown += r.row(row)
+	}
+	return markdown
+}
+
+// columns returns the number of columns of a table without header.
+func (r *Markdown) columns(table Table) int {
+	if len(table.Cols) > 0 {
+		return len(table.Cols)
+	}
+	if len(table.Rows) > 0 {
+		return len(table.Rows[0])
+	}
+	return 1
+}
+
+func (r *Markdown) row(cells []string) string {
+	markdown := "|"
+	for _, cell := range cells {
+		markdown += " " + escapeCell(cell) + " |"
+	}
+	return markdown + "\n"
+}
+
+// List renders an unordered list. The title is rendered as bold paragraph.
+func (r *Markdown) List(title string, items []string) string {
+	markdown := ""
+	if title != "" {
+		markdown += "**" + title + "**\n\n"
+	}
+	for _, item := range items {
+		markdown += "* " + item + "\n"
+	}
+	return markdown
+}
+
+// Link renders a link like `[text](url)`. Links without text are rendered as autolinks.
+func (r *Markdown) Link(url string, text string) string {
+	if text == "" {
+		return "<" + url + ">"
+	}
+	return "[" + text + "](" + url + ")"
+}
+
+// Xref renders a link to another documentation page. Markdown links are resolved relative to the
+// page containing the link, so the target is translated into a relative path.
+func (r *Markdown) Xref(from string, to string, text string) string {
+	target, err := filepath.Rel(filepath.Dir(from), to)
+	if err != nil {
+		target = to
+	}
+	return "[" + text + "](" + filepath.ToSlash(target) + ")"
+}
+
+// Comment renders an HTML comment like `<!-- text -->`, because Markdown has no comment syntax.
+func (r *Markdown) Comment(text string) string {
+	return "<!-- " + text + " -->\n"
+}
@@ -0,0 +1,76 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldRenderMarkdown(t *testing.T) {
+	assert := assert.New(t)
+
+	renderer := &Markdown{}
+	assert.Equal(".md", renderer.FileExtension(), "Incorrect file extension")
+	assert.Equal("# script.sh\n", renderer.Heading(0, "script.sh"), "Incorrect title")
+	assert.Equal("## greet\n", renderer.Heading(1, "greet"), "Incorrect section heading")
+	assert.Equal("[Website](https://sommerfeld.io)", renderer.Link("https://sommerfeld.io", "Website"), "Incorrect link")
+	assert.Equal("<https://sommerfeld.io>", renderer.Link("https://sommerfeld.io", ""), "Incorrect autolink")
+	assert.Equal("**See also**\n\n* a\n* b\n", renderer.List("See also", []string{"a", "b"}), "Incorrect list")
+	assert.Equal("<!-- Lorem ipsum -->\n", renderer.Comment("Lorem ipsum"), "Incorrect comment")
+}
+
+func Test_ShouldRenderMarkdownXrefs(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{from: "src/script-sh.md", to: "src/util-sh.md", expected: "[util.sh](util-sh.md)"},
+		{from: "src/main/script-sh.md", to: "src/lib/util-sh.md", expected: "[util.sh](../lib/util-sh.md)"},
+		{from: "script-sh.md", to: "src/lib/util-sh.md", expected: "[util.sh](src/lib/util-sh.md)"},
+		{from: "/src/script-sh.md", to: "/src/lib/util-sh.md", expected: "[util.sh](lib/util-sh.md)"},
+	}
+
+	renderer := &Markdown{}
+	for _, test := range tests {
+		assert.Equal(test.expected, renderer.Xref(test.from, test.to, "util.sh"), "Incorrect xref from "+test.from)
+	}
+}
+
+func Test_ShouldRenderMarkdownTables(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		table    Table
+		expected string
+	}{
+		{
+			table: Table{Cols: []int{1, 5}, Rows: [][]string{{"Language", "sh"}}},
+			expected: `|  |  |
+| --- | --- |
+| Language | sh |
+`,
+		},
+		{
+			table: Table{
+				Title:  "Arguments",
+				Cols:   []int{1, 1, 5},
+				Header: []string{"Name", "Type", "Description"},
+				Rows:   [][]string{{"$1", "int", "Value with | pipe"}},
+			},
+			expected: `**Arguments**
+
+| Name | Type | Description |
+| --- | --- | --- |
+| $1 | int | Value with \| pipe |
+`,
+		},
+	}
+
+	renderer := &Markdown{}
+	for _, test := range tests {
+		assert.Equal(test.expected, renderer.Table(test.table), "Incorrect table")
+	}
+}
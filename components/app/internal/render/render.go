@@ -0,0 +1,73 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Output formats supported by the app.
+const (
+	FormatAsciiDoc = "asciidoc"
+	FormatMarkdown = "markdown"
+)
+
+// Renderer translates the building blocks of a documentation page (headings, tables, lists and
+// links) into the syntax of an output format. The text from the inline comments is not
+// translated and is written to the documentation page as is.
+type Renderer interface {
+	// Format returns the name of the output format (e.g. `asciidoc`).
+	Format() string
+
+	// FileExtension returns the suffix of the documentation files including the dot (e.g. `.adoc`).
+	FileExtension() string
+
+	// Heading renders a heading. Level 0 is the title of the page, level 1 a top-level section.
+	Heading(level int, text string) string
+
+	// Table renders a table.
+	Table(table Table) string
+
+	// List renders an unordered list with an optional title.
+	List(title string, items []string) string
+
+	// Link renders a link to an external URL. The text is optional.
+	Link(url string, text string) string
+
+	// Xref renders a link from one documentation page to another. Both pages are paths relative
+	// to the output directory.
+	Xref(from string, to string, text string) string
+
+	// Comment renders a single line comment which is not visible in the rendered page.
+	Comment(text string) string
+}
+
+// Table represents a table of a documentation page. The title and the header are optional. The
+// cols define the relative width of each column (if supported by the output format).
+type Table struct {
+	Title  string
+	Cols   []int
+	Header []string
+	Rows   [][]string
+}
+
+// New returns the Renderer for the given output format.
+func New(format string) (Renderer, error) {
+	switch format {
+	case FormatAsciiDoc:
+		return &AsciiDoc{}, nil
+	case FormatMarkdown:
+		return &Markdown{}, nil
+	}
+	return nil, fmt.Errorf("unsupported format: %s (supported formats: %s)", format, strings.Join(Formats(), ", "))
+}
+
+// Formats returns the names of all supported output formats.
+func Formats() []string {
+	return []string{FormatAsciiDoc, FormatMarkdown}
+}
+
+// escapeCell escapes pipes inside a table cell. Both AsciiDoc and Markdown use pipes as cell
+// separators.
+func escapeCell(cell string) string {
+	return strings.ReplaceAll(cell, "|", "\\|")
+}
@@ -0,0 +1,20 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ShouldCreateRendererForFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, format := range Formats() {
+		renderer, err := New(format)
+		assert.Nil(err, "Error creating renderer for "+format)
+		assert.Equal(format, renderer.Format(), "Incorrect format")
+	}
+
+	_, err := New("html")
+	assert.NotNil(err, "Unsupported format should be reported")
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Name    string           `xml:"name,attr"`
+	Tests   int              `xml:"tests,attr"`
+	Errors  int              `xml:"errors,attr"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+// junitTestSuite groups all code files of a run.
+type junitTestSuite struct {
+	Name   string          `xml:"name,attr"`
+	Tests  int             `xml:"tests,attr"`
+	Errors int             `xml:"errors,attr"`
+	Cases  []junitTestCase `xml:"testcase"`
+}
+
+// junitTestCase represents a single code file. The language is used as class name.
+type junitTestCase struct {
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Error     *junitError  `xml:"error,omitempty"`
+	SystemOut *junitOutput `xml:"system-out,omitempty"`
+}
+
+// junitOutput holds the standard output of a test case. The text is written as CDATA section to
+// keep the line breaks readable.
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+// junitError describes why a code file could not be processed.
+type junitError struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnit returns the Report in the JUnit XML format. Each code file is a test case which fails with
+// an error if the code file could not be processed. The output path, the number of parts and the
+// warnings are written to the standard output of the test case. Errors which are not related to a
+// single processed code file are reported as additional test cases.
+func (report *Report) JUnit() ([]byte, error) {
+	suite := junitTestSuite{Name: "source2adoc", Cases: []junitTestCase{}}
+	for _, entry := range report.Files {
+		suite.Cases = append(suite.Cases, entry.junit())
+	}
+	for _, err := range report.Errors {
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "discovery",
+			ClassName: "source2adoc",
+			Error:     &junitError{Message: err, Text: err},
+		})
+	}
+
+	for _, testCase := range suite.Cases {
+		suite.Tests++
+		if testCase.Error != nil {
+			suite.Errors++
+		}
+	}
+	suites := junitTestSuites{Name: suite.Name, Tests: suite.Tests, Errors: suite.Errors, Suites: []junitTestSuite{suite}}
+
+	content, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize report: %v", err)
+	}
+	return []byte(xml.Header + string(content) + "\n"), nil
+}
+
+// junit translates the Entry into a JUnit test case.
+func (entry Entry) junit() junitTestCase {
+	testCase := junitTestCase{Name: entry.Source, ClassName: entry.Language}
+	if len(entry.Errors) > 0 {
+		testCase.Error = &junitError{Message: entry.Errors[0], Text: strings.Join(entry.Errors, "\n")}
+	}
+
+	out := []string{fmt.Sprintf("status: %s", entry.Status)}
+	if entry.Output != "" {
+		out = append(out, "output: "+entry.Output)
+	}
+	out = append(out, fmt.Sprintf("parts: %d", entry.Parts))
+	for _, warning := range entry.Warnings {
+		out = append(out, "warning: "+warning)
+	}
+	testCase.SystemOut = &junitOutput{Text: strings.Join(out, "\n")}
+	return testCase
+}
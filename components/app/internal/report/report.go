@@ -0,0 +1,115 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Status describes what happened to a code file during the run.
+type Status string
+
+const (
+	// StatusParsed marks code files which were parsed without creating a documentation file (e.g.
+	// for the JSON export).
+	StatusParsed Status = "parsed"
+
+	// StatusGenerated marks code files whose documentation file was created in memory only.
+	StatusGenerated Status = "generated"
+
+	// StatusWritten marks code files whose documentation file was written.
+	StatusWritten Status = "written"
+
+	// StatusUnchanged marks code files whose documentation file was not written because they did
+	// not change since the previous run. These code files are parsed anyway, so their parts and
+	// warnings are known.
+	StatusUnchanged Status = "unchanged"
+
+	// StatusFailed marks code files which could not be processed.
+	StatusFailed Status = "failed"
+)
+
+// Entry describes a single processed code file.
+type Entry struct {
+	Source   string   `json:"source"`
+	Language string   `json:"language"`
+	Output   string   `json:"output,omitempty"`
+	Status   Status   `json:"status"`
+	Parts    int      `json:"parts"`
+	Warnings []string `json:"warnings"`
+	Errors   []string `json:"errors"`
+}
+
+// Report describes a run of the app with all processed code files. Errors which are not related to
+// a single processed code file (e.g. folders which could not be searched) are listed separately.
+// The Report can be updated and written concurrently (e.g. while the code files are searched in
+// the background).
+type Report struct {
+	mutex  sync.Mutex
+	Files  []Entry  `json:"files"`
+	Errors []string `json:"errors"`
+}
+
+// New acts as a constructor for a new and empty Report instance.
+func New() *Report {
+	return &Report{
+		Files:  []Entry{},
+		Errors: []string{},
+	}
+}
+
+// Add adds an Entry to the Report.
+func (report *Report) Add(entry Entry) {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+	if entry.Warnings == nil {
+		entry.Warnings = []string{}
+	}
+	if entry.Errors == nil {
+		entry.Errors = []string{}
+	}
+	report.Files = append(report.Files, entry)
+}
+
+// AddError adds an error which is not related to a single processed code file.
+func (report *Report) AddError(err error) {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+	report.Errors = append(report.Errors, err.Error())
+}
+
+// Write writes the Report to the given file. Files with the `.xml` extension are written in the
+// JUnit XML format, all other files in the JSON format. Missing directories are created.
+func (report *Report) Write(path string) error {
+	report.mutex.Lock()
+	defer report.mutex.Unlock()
+	content, err := report.JSON()
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		content, err = report.JUnit()
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return fmt.Errorf("failed to create directory: %v", err)
+	}
+	err = os.WriteFile(path, content, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write report: %v", err)
+	}
+	return nil
+}
+
+// JSON returns the Report in the JSON format.
+func (report *Report) JSON() ([]byte, error) {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize report: %v", err)
+	}
+	return append(content, '\n'), nil
+}
@@ -0,0 +1,79 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReport() *Report {
+	report := New()
+	report.Add(Entry{
+		Source:   "src/script.sh",
+		Language: "sh",
+		Output:   "docs/src/script-sh.adoc",
+		Status:   StatusWritten,
+		Parts:    4,
+		Warnings: []string{"no header documentation found"},
+	})
+	report.Add(Entry{
+		Source:   "src/broken.sh",
+		Language: "sh",
+		Status:   StatusFailed,
+		Errors:   []string{"src/broken.sh: failed to read code file"},
+	})
+	report.AddError(errors.New("src/secret: failed to walk the filesystem"))
+	return report
+}
+
+func Test_ShouldWriteJSONReport(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "target", "report.json")
+	err := newTestReport().Write(path)
+	assert.Nil(err, "Error writing report")
+
+	content, err := os.ReadFile(path)
+	assert.Nil(err, "Error reading report")
+	loaded := &Report{}
+	err = json.Unmarshal(content, loaded)
+	assert.Nil(err, "Report should be valid JSON")
+	assert.Equal(newTestReport(), loaded, "Incorrect report")
+	assert.Contains(string(content), `"warnings": []`, "Missing warnings should be written as empty list")
+}
+
+func Test_ShouldWriteJUnitReport(t *testing.T) {
+	assert := assert.New(t)
+
+	path := filepath.Join(t.TempDir(), "report.xml")
+	err := newTestReport().Write(path)
+	assert.Nil(err, "Error writing report")
+
+	content, err := os.ReadFile(path)
+	assert.Nil(err, "Error reading report")
+	expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="source2adoc" tests="3" errors="2">
+  <testsuite name="source2adoc" tests="3" errors="2">
+    <testcase name="src/script.sh" classname="sh">
+      <system-out><![CDATA[status: written
+output: docs/src/script-sh.adoc
+parts: 4
+warning: no header documentation found]]></system-out>
+    </testcase>
+    <testcase name="src/broken.sh" classname="sh">
+      <error message="src/broken.sh: failed to read code file">src/broken.sh: failed to read code file</error>
+      <system-out><![CDATA[status: failed
+parts: 0]]></system-out>
+    </testcase>
+    <testcase name="discovery" classname="source2adoc">
+      <error message="src/secret: failed to walk the filesystem">src/secret: failed to walk the filesystem</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	assert.Equal(expected, string(content), "Incorrect JUnit report")
+}
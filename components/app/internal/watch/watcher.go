@@ -0,0 +1,206 @@
+package watch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher watches directories recursively and reports the changed paths in batches. Changes which
+// occur in quick succession (e.g. an editor writing a file in several steps) are reported as a
+// single batch once no further changes occurred for the given delay. Changes inside ignored
+// directories (e.g. the output directory of the app) are not reported.
+type Watcher struct {
+	dirs    []string
+	ignored []string
+	delay   time.Duration
+	watcher *fsnotify.Watcher
+	changed map[string]bool
+}
+
+// New acts as a constructor for a new Watcher instance. All given directories and their
+// subdirectories are watched right away, except for the ignored directories. Ignored directories
+// which contain a watched directory are not ignored, otherwise nothing would be watched.
+func New(dirs []string, ignored []string, delay time.Duration) (*Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create watcher: %v", err)
+	}
+
+	w := &Watcher{
+		dirs:    dirs,
+		ignored: []string{},
+		delay:   delay,
+		watcher: watcher,
+		changed: map[string]bool{},
+	}
+	for _, dir := range ignored {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("failed to ignore %s: %v", dir, err)
+		}
+		if !w.containsWatchedDir(abs) {
+			w.ignored = append(w.ignored, abs)
+		}
+	}
+	for _, dir := range dirs {
+		err = w.addRecursive(dir)
+		if err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+	// Files which exist right from the start are no changes
+	w.changed = map[string]bool{}
+	return w, nil
+}
+
+// addRecursive watches the given directory and all its subdirectories. Files inside the
+// directories are recorded as changed, because they might have been created before the directory
+// was watched (e.g. when a directory is moved into a watched directory).
+func (w *Watcher) addRecursive(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to watch %s: %v", path, err)
+		}
+		if w.isIgnored(path) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			w.changed[filepath.Clean(path)] = true
+			return nil
+		}
+		err = w.watcher.Add(path)
+		if err != nil {
+			return fmt.Errorf("failed to watch %s: %v", path, err)
+		}
+		return nil
+	})
+}
+
+// Run reports the changed paths to the onChange function until the stop channel is closed. The
+// paths are sorted. Deleted and renamed paths are reported just like created or modified paths,
+// so the receiver needs to check if a path still exists. Directories are reported as a whole, so
+// the receiver needs to treat all paths inside a reported directory as changed.
+func (w *Watcher) Run(stop <-chan struct{}, onChange func(paths []string)) error {
+	timer := time.NewTimer(w.delay)
+	timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			timer.Stop()
+			return nil
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return nil
+			}
+			if w.record(event) {
+				timer.Reset(w.delay)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return nil
+			}
+			if !errors.Is(err, fsnotify.ErrEventOverflow) {
+				return fmt.Errorf("failed to watch for changes: %v", err)
+			}
+			// Changes got lost, so everything might have changed
+			for _, dir := range w.dirs {
+				w.changed[filepath.Clean(dir)] = true
+			}
+			timer.Reset(w.delay)
+		case <-timer.C:
+			onChange(w.flush())
+		}
+	}
+}
+
+// record records the path of the event as changed. New directories are watched as well. Returns
+// false if the event is irrelevant (e.g. only the permissions changed).
+func (w *Watcher) record(event fsnotify.Event) bool {
+	if event.Op == fsnotify.Chmod || w.isIgnored(event.Name) {
+		return false
+	}
+
+	path := filepath.Clean(event.Name)
+	w.changed[path] = true
+
+	if event.Has(fsnotify.Create) {
+		info, err := os.Stat(path)
+		if err == nil && info.IsDir() {
+			// Directories which vanish right away are reported by their own events
+			_ = w.addRecursive(path)
+		}
+	}
+	if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+		// Only watched directories can be removed from the watcher
+		_ = w.watcher.Remove(path)
+	}
+	return true
+}
+
+// containsWatchedDir returns true if one of the watched directories is the given directory or is
+// located inside it.
+func (w *Watcher) containsWatchedDir(dir string) bool {
+	for _, watched := range w.dirs {
+		abs, err := filepath.Abs(watched)
+		if err == nil && IsAffected(abs, []string{dir}) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIgnored returns true if the path is one of the ignored directories or is located inside one of
+// them.
+func (w *Watcher) isIgnored(path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return IsAffected(abs, w.ignored)
+}
+
+// flush returns all paths which changed since the previous flush in sorted order.
+func (w *Watcher) flush() []string {
+	paths := []string{}
+	for path := range w.changed {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	w.changed = map[string]bool{}
+	return paths
+}
+
+// Close stops watching all directories.
+func (w *Watcher) Close() error {
+	return w.watcher.Close()
+}
+
+// IsAffected returns true if the given path is one of the changed paths or is located inside one
+// of the changed paths. Both are cleaned, so `./run.sh` and `run.sh` are the same path and the
+// working directory `.` contains all relative paths.
+func IsAffected(path string, changed []string) bool {
+	path = filepath.Clean(path)
+	for _, candidate := range changed {
+		candidate = filepath.Clean(candidate)
+		if candidate == "." && !filepath.IsAbs(path) {
+			return true
+		}
+		if path == candidate || strings.HasPrefix(path, candidate+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
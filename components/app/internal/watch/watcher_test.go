@@ -0,0 +1,147 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runWatcher runs the watcher in the background and returns a channel receiving all batches.
+func runWatcher(t *testing.T, watcher *Watcher) <-chan []string {
+	batches := make(chan []string, 10)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := watcher.Run(stop, func(paths []string) {
+			batches <- paths
+		})
+		assert.Nil(t, err, "Error watching for changes")
+	}()
+	t.Cleanup(func() {
+		close(stop)
+		<-done
+		watcher.Close()
+	})
+	return batches
+}
+
+// nextBatch waits for the next batch of changed paths.
+func nextBatch(t *testing.T, batches <-chan []string) []string {
+	select {
+	case paths := <-batches:
+		return paths
+	case <-time.After(5 * time.Second):
+		t.Fatal("No changes reported")
+		return nil
+	}
+}
+
+func Test_ShouldReportChangedPathsInBatches(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "sub"), 0755)
+	assert.Nil(err, "Error creating directory")
+	err = os.WriteFile(filepath.Join(dir, "sub/existing.sh"), []byte("## Existing\n"), 0644)
+	assert.Nil(err, "Error creating file")
+
+	watcher, err := New([]string{dir}, []string{}, 100*time.Millisecond)
+	assert.Nil(err, "Error creating watcher")
+	batches := runWatcher(t, watcher)
+
+	err = os.WriteFile(filepath.Join(dir, "new.sh"), []byte("## New\n"), 0644)
+	assert.Nil(err, "Error creating file")
+	err = os.WriteFile(filepath.Join(dir, "sub/existing.sh"), []byte("## Changed\n"), 0644)
+	assert.Nil(err, "Error changing file")
+	assert.Equal([]string{filepath.Join(dir, "new.sh"), filepath.Join(dir, "sub/existing.sh")}, nextBatch(t, batches), "Incorrect changed paths")
+
+	err = os.Rename(filepath.Join(dir, "new.sh"), filepath.Join(dir, "sub/renamed.sh"))
+	assert.Nil(err, "Error renaming file")
+	assert.Equal([]string{filepath.Join(dir, "new.sh"), filepath.Join(dir, "sub/renamed.sh")}, nextBatch(t, batches), "Renames should report both paths")
+
+	err = os.Remove(filepath.Join(dir, "sub/existing.sh"))
+	assert.Nil(err, "Error deleting file")
+	assert.Equal([]string{filepath.Join(dir, "sub/existing.sh")}, nextBatch(t, batches), "Deletes should be reported")
+}
+
+func Test_ShouldWatchNewDirectories(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	watcher, err := New([]string{dir}, []string{}, 100*time.Millisecond)
+	assert.Nil(err, "Error creating watcher")
+	batches := runWatcher(t, watcher)
+
+	err = os.MkdirAll(filepath.Join(dir, "new"), 0755)
+	assert.Nil(err, "Error creating directory")
+	assert.Equal([]string{filepath.Join(dir, "new")}, nextBatch(t, batches), "New directory should be reported")
+
+	err = os.WriteFile(filepath.Join(dir, "new/script.sh"), []byte("## New\n"), 0644)
+	assert.Nil(err, "Error creating file")
+	assert.Equal([]string{filepath.Join(dir, "new/script.sh")}, nextBatch(t, batches), "Files in new directories should be reported")
+}
+
+func Test_ShouldFailToWatchMissingDir(t *testing.T) {
+	_, err := New([]string{filepath.Join(t.TempDir(), "missing")}, []string{}, time.Millisecond)
+	assert.NotNil(t, err, "Missing dir should be reported")
+}
+
+func Test_ShouldDetectAffectedPaths(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		path     string
+		changed  []string
+		expected bool
+	}{
+		{path: "src/script.sh", changed: []string{"src/script.sh"}, expected: true},
+		{path: "src/bin/script.sh", changed: []string{"src/bin"}, expected: true},
+		{path: "src/bin/script.sh", changed: []string{"src"}, expected: true},
+		{path: "src/binary/script.sh", changed: []string{"src/bin"}, expected: false},
+		{path: "src/other.sh", changed: []string{"src/script.sh"}, expected: false},
+		{path: "src/script.sh", changed: []string{}, expected: false},
+		{path: "run.sh", changed: []string{"./run.sh"}, expected: true},
+		{path: "./run.sh", changed: []string{"run.sh"}, expected: true},
+		{path: "run.sh", changed: []string{"."}, expected: true},
+		{path: "/run.sh", changed: []string{"."}, expected: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(test.expected, IsAffected(test.path, test.changed), "Incorrect result for "+test.path)
+	}
+}
+
+func Test_ShouldIgnoreChangesInIgnoredDirs(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dir, "docs"), 0755)
+	assert.Nil(err, "Error creating directory")
+
+	watcher, err := New([]string{dir}, []string{filepath.Join(dir, "docs")}, 100*time.Millisecond)
+	assert.Nil(err, "Error creating watcher")
+	batches := runWatcher(t, watcher)
+
+	err = os.WriteFile(filepath.Join(dir, "docs/script-sh.adoc"), []byte("= script.sh\n"), 0644)
+	assert.Nil(err, "Error creating file")
+	err = os.WriteFile(filepath.Join(dir, "script.sh"), []byte("## Script\n"), 0644)
+	assert.Nil(err, "Error creating file")
+	assert.Equal([]string{filepath.Join(dir, "script.sh")}, nextBatch(t, batches), "Changes in ignored dirs should not be reported")
+}
+
+func Test_ShouldNotIgnoreDirsContainingWatchedDirs(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	watcher, err := New([]string{filepath.Join(dir, ".")}, []string{dir}, 100*time.Millisecond)
+	assert.Nil(err, "Error creating watcher")
+	batches := runWatcher(t, watcher)
+
+	err = os.WriteFile(filepath.Join(dir, "script.sh"), []byte("## Script\n"), 0644)
+	assert.Nil(err, "Error creating file")
+	assert.Equal([]string{filepath.Join(dir, "script.sh")}, nextBatch(t, batches), "Watched dir should not be ignored")
+}
@@ -63,6 +63,22 @@ Feature: Exclude Files and Folders from AsciiDoc Documentation Generation
       | /workspaces/source2adoc/testdata/common/good/docker   | /workspaces/source2adoc/testdata/common/good/yaml      |
       | /workspaces/source2adoc/testdata/common/good/docker   | /workspaces/source2adoc/testdata/common/good/script.sh |
 
+  Scenario Outline: Exclude files and folders by name at any depth
+    Given I specify the "--source-dir" flag with value "/workspaces/source2adoc/testdata/common"
+    And I specify the "--output-dir" flag with value "/workspaces/source2adoc/target/acceptance-test"
+    And I specify the "--exclude" flag with value "<exclude>"
+    When I run the app
+    Then exit code should be 0
+    And AsciiDoc files should be generated for all source code files
+    But no AsciiDoc file should be generated for "good/<exclude>"
+
+    Examples:
+      | exclude   |
+      | docker    |
+      | yaml      |
+      | script.sh |
+      | Makefile  |
+
   Scenario: Exclude flag without value
     Given I specify the "--source-dir" flag with value "/workspaces/source2adoc/testdata/common"
     And I specify the "--output-dir" flag with value "/workspaces/source2adoc/target/acceptance-test"
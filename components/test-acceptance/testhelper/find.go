@@ -18,7 +18,7 @@ func FindSourceCodeFiles(sourceDir string, excludes []string) ([]string, error)
 		}
 
 		for _, exclude := range excludes {
-			if strings.Contains(path, exclude) {
+			if isExcluded(sourceDir, path, exclude) {
 				return nil
 			}
 		}
@@ -42,6 +42,30 @@ func FindSourceCodeFiles(sourceDir string, excludes []string) ([]string, error)
 	return codeFiles, nil
 }
 
+// isExcluded mimics the exclude rules of the app for plain paths (no glob patterns). Excludes
+// without slash match a file or folder name at any depth. All other excludes are anchored at the
+// source dir (unless they are absolute) and match the path itself and everything inside.
+func isExcluded(sourceDir string, path string, exclude string) bool {
+	if !strings.Contains(exclude, "/") {
+		rel, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return false
+		}
+		for _, segment := range strings.Split(filepath.ToSlash(rel), "/") {
+			if segment == exclude {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !filepath.IsAbs(exclude) {
+		exclude = filepath.Join(sourceDir, exclude)
+	}
+	exclude = filepath.Clean(exclude)
+	return path == exclude || strings.HasPrefix(path, exclude+string(filepath.Separator))
+}
+
 func matchesFilenamePattern(filename string) bool {
 	return strings.HasPrefix(filename, "Dockerfile") ||
 		strings.HasSuffix(filename, ".yml") ||
@@ -34,6 +34,18 @@ func Test_ShouldFindSourceCodeFiles(t *testing.T) {
 				filepath.Join(TestDataPath, "Vagrantfile"),
 			},
 		},
+		{
+			expectedCount: 6,
+			excludes:      []string{"docker"},
+		},
+		{
+			expectedCount: 8,
+			excludes:      []string{"docker/Dockerfile"},
+		},
+		{
+			expectedCount: 9,
+			excludes:      []string{"good/docker"},
+		},
 	}
 
 	for _, test := range tests {
@@ -9,3 +9,16 @@
 ## @arg $1 string Lorem ipsum dolor sit amet, consetetur sadipscing elitr
 ## @arg $2 string Sed diam nonumy eirmod tempor invidunt
 ## @arg $3 string Ut labore et dolore magna aliquyam erat, sed diam voluptua
+
+## Print a greeting to stdout.
+##
+## @arg $1 string The name to greet
+function greet {
+    echo "Hello $1"
+}
+
+## Lorem ipsum dolor sit amet, consetetur sadipscing elitr, sed diam nonumy eirmod tempor invidunt
+## ut labore et dolore magna aliquyam erat, sed diam voluptua.
+lorem() {
+    echo "Lorem ipsum"
+}